@@ -33,6 +33,15 @@ containerEngines.socket will render the socket for the selected container runtim
 {{- $engineValues.socket -}}
 {{- end -}}
 
+{{- /*
+stateDir.hostPath will render the host directory for the attack journal, gke-autopilot doesn't allow to mount it
+*/}}
+{{- define "stateDir.hostPath" -}}
+{{- if ne .Values.platform "gke-autopilot" -}}
+{{- .Values.stateDir.hostPath | default "" -}}
+{{- end -}}
+{{- end -}}
+
 {{- /*
 will omit attribute from the passed in object depending on the KubeVersion
 */}}
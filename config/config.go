@@ -4,8 +4,10 @@ package config
 
 import (
 	"flag"
+	"fmt"
 	"os"
 	"strings"
+	"sync/atomic"
 
 	"github.com/gobwas/glob"
 	"github.com/kelseyhightower/envconfig"
@@ -16,7 +18,7 @@ type Specification struct {
 	ContainerSocket             string           `json:"containerSocket" split_words:"true" required:"false"`
 	ContainerRuntime            string           `json:"containerRuntime" split_words:"true" required:"false"`
 	ContainerdNamespace         string           `json:"containerdNamespace" split_words:"true" required:"true" default:"k8s.io"`
-	DisableDiscoveryExcludes    bool             `required:"false" split_words:"true" default:"false"`
+	DisableDiscoveryExcludes    bool             `json:"disableDiscoveryExcludes" required:"false" split_words:"true" default:"false"`
 	DiscoveryCallInterval       string           `json:"discoveryCallInterval" split_words:"true" required:"false" default:"15s"`
 	DiscoveryAttributesExcludes []string         `json:"discoveryAttributesExcludes" split_words:"true" required:"false" default:"container.label.io.buildpacks.lifecycle.metadata,container.label.io.buildpacks.build.metadata"`
 	Port                        uint16           `json:"port" split_words:"true" required:"false" default:"8086"`
@@ -35,26 +37,118 @@ type Specification struct {
 	//     preserved instead of being reset to kernel defaults.
 	// STEADYBIT_EXTENSION_NETWORK_STRICT_ROOT_QDISC
 	NetworkStrictRootQdisc bool `json:"networkStrictRootQdisc" split_words:"true" required:"false" default:"true"`
+	// StateDir is where the attack journal is written to, so attacks can be reverted after the extension was restarted.
+	// It must survive a restart of the extension, e.g. a volume when running in a container. An empty value keeps the
+	// journal in memory only.
+	// STEADYBIT_EXTENSION_STATE_DIR
+	StateDir string `json:"stateDir" split_words:"true" required:"false"`
+	// ContainerRuntimes configures several runtimes to be used at once as runtime:socket pairs, e.g.
+	// docker:/var/run/docker.sock,containerd:/run/containerd/containerd.sock. An empty socket uses the default socket
+	// of the runtime. Takes precedence over ContainerRuntime and ContainerSocket.
+	// STEADYBIT_EXTENSION_CONTAINER_RUNTIMES
+	ContainerRuntimes map[string]string `json:"containerRuntimes" split_words:"true" required:"false"`
+	// OciRuntimeRoots configures the OCI runtime root per runtime as runtime:root pairs, e.g.
+	// docker:/run/docker/runtime-runc/moby. Runtimes without an entry use their default root.
+	// STEADYBIT_EXTENSION_OCIRUNTIME_ROOTS
+	OciRuntimeRoots map[string]string `json:"ociRuntimeRoots" envconfig:"OCIRUNTIME_ROOTS" required:"false"`
+	// LabelAttributePresets enables the built-in label mappings of common orchestrators (compose, ecs, nomad, swarm).
+	// STEADYBIT_EXTENSION_LABEL_ATTRIBUTE_PRESETS
+	LabelAttributePresets []LabelAttributePreset `json:"labelAttributePresets" split_words:"true" required:"false"`
+	// LabelAttributes maps container labels to target attributes using <label>=<attribute> rules, see LabelAttributeRule.
+	// The labels are reported as container.label.<key> as well.
+	// STEADYBIT_EXTENSION_LABEL_ATTRIBUTES
+	LabelAttributes []LabelAttributeRule `json:"labelAttributes" split_words:"true" required:"false"`
+	// ExcludeContainers are rules excluding containers from discovery and attacks, separated by semicolons, see
+	// ContainerRule.
+	// STEADYBIT_EXTENSION_EXCLUDE_CONTAINERS
+	ExcludeContainers ContainerRules `json:"excludeContainers" split_words:"true" required:"false"`
+	// DisallowContainers are rules disallowing attacks on containers by their labels, image, name, id or runtime,
+	// separated by semicolons, see ContainerRule. Unlike DisallowK8sNamespaces the containers are still discovered.
+	// STEADYBIT_EXTENSION_DISALLOW_CONTAINERS
+	DisallowContainers ContainerRules `json:"disallowContainers" split_words:"true" required:"false"`
+	// MaxConcurrentAttacks limits the attacks running on this host at once, 0 is unlimited.
+	// STEADYBIT_EXTENSION_MAX_CONCURRENT_ATTACKS
+	MaxConcurrentAttacks int `json:"maxConcurrentAttacks" split_words:"true" required:"false" default:"0"`
+	// MaxAttackedContainersPercent limits the percentage of the discovered containers on this host under attack at
+	// once, 0 is unlimited.
+	// STEADYBIT_EXTENSION_MAX_ATTACKED_CONTAINERS_PERCENT
+	MaxAttackedContainersPercent int `json:"maxAttackedContainersPercent" split_words:"true" required:"false" default:"0"`
+	// MaxAttacksPerK8sNamespace limits the attacks running on this host at once per Kubernetes namespace, 0 is
+	// unlimited.
+	// STEADYBIT_EXTENSION_MAX_ATTACKS_PER_K8S_NAMESPACE
+	MaxAttacksPerK8sNamespace int `json:"maxAttacksPerK8sNamespace" split_words:"true" required:"false" default:"0"`
+	// DryRun runs all attacks as dry run: they are prepared as usual, but start only reports what would be done.
+	// STEADYBIT_EXTENSION_DRY_RUN
+	DryRun bool `json:"dryRun" split_words:"true" required:"false" default:"false"`
+	// AuditLogFile is the file the audit log is appended to, empty to not write it to a file.
+	// STEADYBIT_EXTENSION_AUDIT_LOG_FILE
+	AuditLogFile string `json:"auditLogFile" split_words:"true" required:"false"`
+	// AuditLogStdout writes the audit log to stdout as well, separate from the log of the extension.
+	// STEADYBIT_EXTENSION_AUDIT_LOG_STDOUT
+	AuditLogStdout bool `json:"auditLogStdout" split_words:"true" required:"false" default:"false"`
+	// AuditLogMaxSizeMb is the size the audit log file is rotated at.
+	// STEADYBIT_EXTENSION_AUDIT_LOG_MAX_SIZE_MB
+	AuditLogMaxSizeMb int `json:"auditLogMaxSizeMb" split_words:"true" required:"false" default:"10"`
+	// AuditLogMaxBackups is the number of rotated audit log files to keep.
+	// STEADYBIT_EXTENSION_AUDIT_LOG_MAX_BACKUPS
+	AuditLogMaxBackups int `json:"auditLogMaxBackups" split_words:"true" required:"false" default:"5"`
+	// EmergencyStopToken enables the /emergency-stop endpoint reverting all active attacks, which has to be called
+	// using the token as bearer token. The endpoint is disabled when empty.
+	// STEADYBIT_EXTENSION_EMERGENCY_STOP_TOKEN
+	EmergencyStopToken string `json:"-" split_words:"true" required:"false"`
+	// ConfigFile is a YAML or JSON file holding settings, which take precedence over the environment variables. Some of
+	// the settings are reloaded when the file changes, see reloadableSettings.
+	// STEADYBIT_EXTENSION_CONFIG_FILE
+	ConfigFile string `json:"-" split_words:"true" required:"false"`
 }
 
 var (
 	Config Specification
+	// current is Config with the settings reloaded from the config file applied
+	current atomic.Pointer[Specification]
 )
 
 func ParseConfiguration() {
-	if err := envconfig.Process("steadybit_extension", &Config); err != nil {
-		log.Fatal().Err(err).Msgf("Failed to parse configuration from environment.")
+	spec, err := load()
+	if err != nil {
+		log.Fatal().Err(err).Msgf("Failed to parse configuration.")
+	}
+	Config = spec
+}
+
+// Current returns the configuration including the settings reloaded from the config file since the start. Settings
+// which can be reloaded must be read using Current, all others can be read from Config.
+func Current() *Specification {
+	if spec := current.Load(); spec != nil {
+		return spec
+	}
+	return &Config
+}
+
+// load reads the configuration from the environment, the config file and the command line arguments
+func load() (Specification, error) {
+	var spec Specification
+	if err := envconfig.Process("steadybit_extension", &spec); err != nil {
+		return spec, fmt.Errorf("failed to parse configuration from environment: %w", err)
+	}
+
+	if spec.ConfigFile != "" {
+		if err := readConfigFile(spec.ConfigFile, &spec); err != nil {
+			return spec, err
+		}
 	}
 
-	if err := parseArgs(&Config); err != nil {
-		log.Fatal().Err(err).Msgf("Failed to parse command line arguments.")
+	if err := parseArgs(&spec); err != nil {
+		return spec, fmt.Errorf("failed to parse command line arguments: %w", err)
 	}
+	return spec, nil
 }
 
 func parseArgs(cfg *Specification) error {
 	f := flag.NewFlagSet("config", flag.ContinueOnError)
 	var disallowHostNetwork = f.Bool("disallowHostNetwork", false, "Disallow network attacks on host network containers")
 	var disallowK8sNamespaces = f.String("disallowK8sNamespaces", "", "Disallow attacks on these k8s namespaces")
+	var disallowContainers = f.String("disallowContainers", "", "Disallow attacks on containers matching these rules")
 
 	if err := f.Parse(os.Args[1:]); err != nil {
 		return err
@@ -73,18 +167,30 @@ func parseArgs(cfg *Specification) error {
 		cfg.DisallowK8sNamespaces = append(cfg.DisallowK8sNamespaces, d)
 	}
 
+	var rules ContainerRules
+	if err := rules.Decode(*disallowContainers); err != nil {
+		return err
+	}
+	cfg.DisallowContainers = append(cfg.DisallowContainers, rules...)
+
 	return nil
 }
 
 func ValidateConfiguration() {
+	if err := Config.Validate(); err != nil {
+		log.Fatal().Msgf("Invalid configuration:\n%s", err)
+	}
 	if Config.DisableDiscoveryExcludes {
 		log.Info().Msg("Discovery excludes are disabled. Will also discover containers labeled with steadybit.com/discovery-disabled.")
 	}
 }
 
+// DisallowedName is a glob matching names. A pattern failing to compile doesn't fail decoding, so all invalid patterns
+// are reported at once by Specification.Validate, it doesn't match anything.
 type DisallowedName struct {
-	p string
-	g glob.Glob
+	p   string
+	g   glob.Glob
+	err error
 }
 
 func (d DisallowedName) String() string {
@@ -92,15 +198,24 @@ func (d DisallowedName) String() string {
 }
 
 func (d DisallowedName) Match(value string) bool {
-	return d.g.Match(value)
+	return d.g != nil && d.g.Match(value)
+}
+
+// Err returns the error compiling the pattern
+func (d DisallowedName) Err() error {
+	return d.err
+}
+
+func (d DisallowedName) MarshalText() ([]byte, error) {
+	return []byte(d.p), nil
+}
+
+func (d *DisallowedName) UnmarshalText(text []byte) error {
+	return d.Decode(string(text))
 }
 
 func (d *DisallowedName) Decode(value string) error {
-	g, err := glob.Compile(value)
-	if err != nil {
-		return err
-	}
-	d.g = g
 	d.p = value
+	d.g, d.err = glob.Compile(value)
 	return nil
 }
@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"sigs.k8s.io/yaml"
+)
+
+// configFileCheckInterval is how often the config file is checked for changes. The file is polled, as config maps
+// mounted by Kubernetes are updated by swapping a symlink, which is easily missed by file watches.
+const configFileCheckInterval = 10 * time.Second
+
+// reloadableSettings are the settings applied when the config file changes, all others require a restart of the
+// extension. They are read on each discovery run or attack, so they can be swapped at any time.
+var reloadableSettings = []string{
+	"DisallowHostNetwork",
+	"DisallowK8sNamespaces",
+	"DisallowContainers",
+	"ExcludeContainers",
+	"DisableDiscoveryExcludes",
+	"DiscoveryAttributesExcludes",
+	"MaxConcurrentAttacks",
+	"MaxAttackedContainersPercent",
+	"MaxAttacksPerK8sNamespace",
+}
+
+func readConfigFile(path string, spec *Specification) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	if err := yaml.UnmarshalStrict(content, spec); err != nil {
+		return fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// WatchConfigFile reloads the settings when the config file changes, until the context is done.
+func WatchConfigFile(ctx context.Context) {
+	path := Config.ConfigFile
+	if path == "" {
+		return
+	}
+
+	last, _ := os.ReadFile(path)
+	go func() {
+		ticker := time.NewTicker(configFileCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			content, err := os.ReadFile(path)
+			if err != nil {
+				log.Debug().Err(err).Str("file", path).Msg("Failed to read config file, keeping the current configuration.")
+				continue
+			}
+			if bytes.Equal(content, last) {
+				continue
+			}
+			last = content
+
+			if err := reload(); err != nil {
+				log.Error().Err(err).Str("file", path).Msg("Failed to reload config file, keeping the current configuration.")
+			}
+		}
+	}()
+}
+
+// reload loads the configuration again and swaps the reloadable settings that changed
+func reload() error {
+	loaded, err := load()
+	if err != nil {
+		return err
+	}
+	if err := loaded.validateReloadable(); err != nil {
+		return err
+	}
+
+	next := *Current()
+	nextValue := reflect.ValueOf(&next).Elem()
+	loadedValue := reflect.ValueOf(loaded)
+	for i := range nextValue.NumField() {
+		field := nextValue.Type().Field(i)
+		old, err := json.Marshal(nextValue.Field(i).Interface())
+		if err != nil {
+			return err
+		}
+		changed, err := json.Marshal(loadedValue.Field(i).Interface())
+		if err != nil {
+			return err
+		}
+		if bytes.Equal(old, changed) {
+			continue
+		}
+
+		if !slices.Contains(reloadableSettings, field.Name) {
+			log.Warn().Str("setting", settingName(field)).Msg("Setting changed in config file, restart the extension to apply it.")
+			continue
+		}
+		log.Info().Str("setting", settingName(field)).RawJSON("from", old).RawJSON("to", changed).Msg("Reloaded setting from config file.")
+		nextValue.Field(i).Set(loadedValue.Field(i))
+	}
+
+	current.Store(&next)
+	return nil
+}
+
+// validateReloadable validates the settings which aren't validated by parsing them already
+func (s *Specification) validateReloadable() error {
+	var errs []error
+	if s.MaxConcurrentAttacks < 0 {
+		errs = append(errs, fmt.Errorf("maxConcurrentAttacks must not be negative, got %d", s.MaxConcurrentAttacks))
+	}
+	if s.MaxAttackedContainersPercent < 0 || s.MaxAttackedContainersPercent > 100 {
+		errs = append(errs, fmt.Errorf("maxAttackedContainersPercent must be between 0 and 100, got %d", s.MaxAttackedContainersPercent))
+	}
+	if s.MaxAttacksPerK8sNamespace < 0 {
+		errs = append(errs, fmt.Errorf("maxAttacksPerK8sNamespace must not be negative, got %d", s.MaxAttacksPerK8sNamespace))
+	}
+	for _, name := range s.DisallowK8sNamespaces {
+		if err := name.Err(); err != nil {
+			errs = append(errs, fmt.Errorf("disallowK8sNamespaces: invalid glob %q: %w", name.String(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func settingName(field reflect.StructField) string {
+	if name, _, _ := strings.Cut(field.Tag.Get("json"), ","); name != "" && name != "-" {
+		return name
+	}
+	return field.Name
+}
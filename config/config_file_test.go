@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func withConfigFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	t.Setenv("STEADYBIT_EXTENSION_CONFIG_FILE", path)
+
+	oldArgs, oldConfig := os.Args, Config
+	os.Args = []string{"extension"}
+	t.Cleanup(func() {
+		os.Args = oldArgs
+		Config = oldConfig
+		current.Store(nil)
+	})
+	return path
+}
+
+func Test_load_configFile(t *testing.T) {
+	withConfigFile(t, `
+discoveryCallInterval: 5s
+disallowK8sNamespaces: [kube-*]
+excludeContainers:
+  - label:app=web AND image=*/nginx:*
+maxConcurrentAttacks: 2
+`)
+	t.Setenv("STEADYBIT_EXTENSION_MAX_CONCURRENT_ATTACKS", "5")
+	t.Setenv("STEADYBIT_EXTENSION_MAX_ATTACKS_PER_K8S_NAMESPACE", "1")
+
+	spec, err := load()
+	require.NoError(t, err)
+	assert.Equal(t, "5s", spec.DiscoveryCallInterval)
+	assert.Equal(t, []DisallowedName{mustParseDisallowedName("kube-*")}, spec.DisallowK8sNamespaces)
+	assert.Equal(t, mustParseContainerRules("label:app=web AND image=*/nginx:*"), spec.ExcludeContainers)
+	// the file takes precedence over the environment
+	assert.Equal(t, 2, spec.MaxConcurrentAttacks)
+	assert.Equal(t, 1, spec.MaxAttacksPerK8sNamespace)
+	// defaults apply to settings missing in the file
+	assert.Equal(t, "k8s.io", spec.ContainerdNamespace)
+}
+
+func Test_load_invalidConfigFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{name: "unknown setting", content: "maxConcurrentAttack: 2", wantErr: `unknown field "maxConcurrentAttack"`},
+		{name: "invalid rule", content: "disallowContainers: [host=node-1]", wantErr: "invalid container rule"},
+		{name: "invalid preset", content: "labelAttributePresets: [k8s]", wantErr: "unknown label attribute preset"},
+		{name: "invalid yaml", content: "maxConcurrentAttacks: [", wantErr: "invalid config file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withConfigFile(t, tt.content)
+			_, err := load()
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func Test_reload(t *testing.T) {
+	path := withConfigFile(t, `
+discoveryCallInterval: 5s
+maxConcurrentAttacks: 2
+`)
+	spec, err := load()
+	require.NoError(t, err)
+	Config = spec
+
+	require.NoError(t, os.WriteFile(path, []byte(`
+discoveryCallInterval: 10s
+maxConcurrentAttacks: 3
+disallowK8sNamespaces: [kube-system]
+`), 0o600))
+	require.NoError(t, reload())
+
+	assert.Equal(t, 3, Current().MaxConcurrentAttacks)
+	assert.Equal(t, []DisallowedName{mustParseDisallowedName("kube-system")}, Current().DisallowK8sNamespaces)
+	// requires a restart
+	assert.Equal(t, "5s", Current().DiscoveryCallInterval)
+	// Config keeps the settings of the start
+	assert.Equal(t, 2, Config.MaxConcurrentAttacks)
+
+	require.NoError(t, os.WriteFile(path, []byte(`
+maxConcurrentAttacks: 4
+maxAttackedContainersPercent: 150
+`), 0o600))
+	assert.ErrorContains(t, reload(), "maxAttackedContainersPercent must be between 0 and 100")
+	assert.Equal(t, 3, Current().MaxConcurrentAttacks)
+
+	require.NoError(t, os.WriteFile(path, []byte(`maxConcurrentAttacks: 4`), 0o600))
+	require.NoError(t, reload())
+	assert.Equal(t, 4, Current().MaxConcurrentAttacks)
+	assert.Empty(t, Current().DisallowK8sNamespaces)
+}
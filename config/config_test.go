@@ -38,6 +38,20 @@ func Test_parseArgs(t *testing.T) {
 				DisallowK8sNamespaces: []DisallowedName{mustParseDisallowedName("test1"), mustParseDisallowedName("test2-*")},
 			},
 		},
+		{
+			name:   "Should add disallowed container rules",
+			args:   []string{"-disallowContainers=image=*/postgres:*; label:tier=storage"},
+			config: Specification{},
+			wantConfig: Specification{
+				DisallowContainers: mustParseContainerRules("image=*/postgres:*; label:tier=storage"),
+			},
+		},
+		{
+			name:    "Should fail on invalid container rules",
+			args:    []string{"-disallowContainers=host=node-1"},
+			config:  Specification{},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -61,3 +75,11 @@ func mustParseDisallowedName(s string) DisallowedName {
 		g: glob.MustCompile(s),
 	}
 }
+
+func mustParseContainerRules(s string) ContainerRules {
+	var rules ContainerRules
+	if err := rules.Decode(s); err != nil {
+		panic(err)
+	}
+	return rules
+}
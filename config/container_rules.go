@@ -0,0 +1,241 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/gobwas/glob"
+)
+
+// ContainerRules are rules selecting containers, e.g. to exclude them from discovery or to disallow attacks on them.
+// They are separated by semicolons, a container is selected if any of the rules matches.
+type ContainerRules []ContainerRule
+
+func (r *ContainerRules) Decode(value string) error {
+	var rules ContainerRules
+	for part := range strings.SplitSeq(value, ";") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		var rule ContainerRule
+		if err := rule.Decode(part); err != nil {
+			return err
+		}
+		rules = append(rules, rule)
+	}
+	*r = rules
+	return nil
+}
+
+// Match returns the first rule matching the container
+func (r ContainerRules) Match(container ContainerProperties) (ContainerRule, bool) {
+	for _, rule := range r {
+		if rule.Matches(container) {
+			return rule, true
+		}
+	}
+	return ContainerRule{}, false
+}
+
+// ContainerProperties holds the properties of a container the rules are evaluated against
+type ContainerProperties struct {
+	Id      string
+	Name    string
+	Image   string
+	Runtime string
+	Labels  map[string]string
+}
+
+// ContainerRule is a conjunction of comparisons of container properties, e.g.
+// `label:app=web AND image~="^.*/istio/proxyv2:.*$"`. The properties are id, name, image, runtime and label:<key>.
+// The operators are = and != comparing against a glob, ~= and !~ comparing against a regular expression. A label
+// without operator matches if the container has the label.
+type ContainerRule struct {
+	p     string
+	terms []containerRuleTerm
+}
+
+type containerRuleTerm struct {
+	field   string
+	label   string
+	negated bool
+	exists  bool
+	glob    glob.Glob
+	re      *regexp.Regexp
+}
+
+func (r ContainerRule) String() string {
+	return r.p
+}
+
+func (r ContainerRule) MarshalText() ([]byte, error) {
+	return []byte(r.p), nil
+}
+
+func (r *ContainerRule) UnmarshalText(text []byte) error {
+	return r.Decode(string(text))
+}
+
+func (r *ContainerRule) Decode(value string) error {
+	rule := ContainerRule{p: strings.TrimSpace(value)}
+	p := containerRuleParser{runes: []rune(value)}
+	for {
+		p.skipSpaces()
+		if len(rule.terms) > 0 {
+			if p.done() {
+				break
+			}
+			if word := p.read(unicode.IsSpace); !strings.EqualFold(word, "and") {
+				return fmt.Errorf("invalid container rule %q: expected 'AND' but got %q", rule.p, word)
+			}
+			p.skipSpaces()
+		}
+		term, err := p.term()
+		if err != nil {
+			return fmt.Errorf("invalid container rule %q: %w", rule.p, err)
+		}
+		rule.terms = append(rule.terms, term)
+	}
+	*r = rule
+	return nil
+}
+
+// Matches returns whether all comparisons of the rule match the container
+func (r ContainerRule) Matches(container ContainerProperties) bool {
+	for _, term := range r.terms {
+		if !term.matches(container) {
+			return false
+		}
+	}
+	return len(r.terms) > 0
+}
+
+func (t containerRuleTerm) matches(container ContainerProperties) bool {
+	var value string
+	switch t.field {
+	case "id":
+		value = container.Id
+	case "name":
+		value = strings.TrimPrefix(container.Name, "/")
+	case "image":
+		value = container.Image
+	case "runtime":
+		value = container.Runtime
+	case "label":
+		var ok bool
+		if value, ok = container.Labels[t.label]; !ok {
+			// a missing label neither equals nor matches anything
+			return t.negated
+		}
+		if t.exists {
+			return true
+		}
+	}
+
+	var matched bool
+	if t.re != nil {
+		matched = t.re.MatchString(value)
+	} else {
+		matched = t.glob.Match(value)
+	}
+	return matched != t.negated
+}
+
+type containerRuleParser struct {
+	runes []rune
+	pos   int
+}
+
+func (p *containerRuleParser) done() bool {
+	return p.pos >= len(p.runes)
+}
+
+func (p *containerRuleParser) skipSpaces() {
+	for !p.done() && unicode.IsSpace(p.runes[p.pos]) {
+		p.pos++
+	}
+}
+
+// read reads until the rune stopping the token or the end of the rule
+func (p *containerRuleParser) read(stop func(rune) bool) string {
+	start := p.pos
+	for !p.done() && !stop(p.runes[p.pos]) {
+		p.pos++
+	}
+	return string(p.runes[start:p.pos])
+}
+
+func (p *containerRuleParser) term() (containerRuleTerm, error) {
+	field := p.read(func(r rune) bool { return unicode.IsSpace(r) || strings.ContainsRune("=!~", r) })
+	if field == "" {
+		return containerRuleTerm{}, fmt.Errorf("expected a property at position %d", p.pos)
+	}
+
+	var term containerRuleTerm
+	if label, ok := strings.CutPrefix(field, "label:"); ok && label != "" {
+		term.field, term.label = "label", label
+	} else if field == "id" || field == "name" || field == "image" || field == "runtime" {
+		term.field = field
+	} else {
+		return containerRuleTerm{}, fmt.Errorf("unknown property %q, expected id, name, image, runtime or label:<key>", field)
+	}
+
+	p.skipSpaces()
+	operator := p.read(func(r rune) bool { return !strings.ContainsRune("=!~", r) })
+	if operator == "" && term.field == "label" {
+		term.exists = true
+		return term, nil
+	}
+
+	if !slices.Contains([]string{"=", "!=", "~=", "!~"}, operator) {
+		return containerRuleTerm{}, fmt.Errorf("expected '=', '!=', '~=' or '!~' after %q but got %q", field, operator)
+	}
+	term.negated = strings.HasPrefix(operator, "!")
+
+	p.skipSpaces()
+	value, err := p.value()
+	if err != nil {
+		return containerRuleTerm{}, err
+	}
+	if strings.Contains(operator, "~") {
+		if term.re, err = regexp.Compile(value); err != nil {
+			return containerRuleTerm{}, fmt.Errorf("invalid regular expression %q: %w", value, err)
+		}
+	} else if term.glob, err = glob.Compile(value); err != nil {
+		return containerRuleTerm{}, fmt.Errorf("invalid glob %q: %w", value, err)
+	}
+	return term, nil
+}
+
+// value reads a bare or a double-quoted value, within quotes \" and \\ are unescaped
+func (p *containerRuleParser) value() (string, error) {
+	if p.done() || p.runes[p.pos] != '"' {
+		value := p.read(unicode.IsSpace)
+		if value == "" {
+			return "", fmt.Errorf("expected a value at position %d", p.pos)
+		}
+		return value, nil
+	}
+
+	start := p.pos
+	var value strings.Builder
+	for p.pos++; !p.done(); p.pos++ {
+		switch r := p.runes[p.pos]; {
+		case r == '"':
+			p.pos++
+			return value.String(), nil
+		case r == '\\' && p.pos+1 < len(p.runes) && strings.ContainsRune(`"\`, p.runes[p.pos+1]):
+			p.pos++
+			value.WriteRune(p.runes[p.pos])
+		default:
+			value.WriteRune(r)
+		}
+	}
+	return "", fmt.Errorf("unterminated string at position %d", start)
+}
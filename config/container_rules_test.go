@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ContainerRule(t *testing.T) {
+	container := ContainerProperties{
+		Id:      "0123456789ab",
+		Name:    "/istio-proxy",
+		Image:   "docker.io/istio/proxyv2:1.24.0",
+		Runtime: "docker",
+		Labels:  map[string]string{"app": "web", "tier": "frontend"},
+	}
+
+	tests := []struct {
+		rule string
+		want bool
+	}{
+		{rule: "image=*/istio/proxyv2*", want: true},
+		{rule: "image=*/istio/pilot*", want: false},
+		{rule: "name=istio-proxy", want: true},
+		{rule: `name~="^istio-.*$"`, want: true},
+		{rule: "name!~^istio-", want: false},
+		{rule: "runtime=containerd", want: false},
+		{rule: "label:app=web AND label:tier!=backend", want: true},
+		{rule: "label:app = web and runtime = docker", want: true},
+		{rule: "label:app=web AND label:tier=backend", want: false},
+		{rule: "label:tier", want: true},
+		{rule: "label:team", want: false},
+		{rule: "label:team!=checkout", want: true},
+		{rule: "label:team~=.*", want: false},
+		{rule: `label:app="w\"eb"`, want: false},
+		{rule: "id=0123*", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			var rule ContainerRule
+			require.NoError(t, rule.Decode(tt.rule))
+			assert.Equal(t, tt.want, rule.Matches(container))
+		})
+	}
+}
+
+func Test_ContainerRule_invalid(t *testing.T) {
+	tests := []struct {
+		rule    string
+		wantErr string
+	}{
+		{rule: "", wantErr: "expected a property"},
+		{rule: "image", wantErr: "expected '=', '!=', '~=' or '!~'"},
+		{rule: "host=node-1", wantErr: "unknown property"},
+		{rule: "label:app=web OR label:app=db", wantErr: "expected 'AND'"},
+		{rule: "label:app=web AND", wantErr: "expected a property"},
+		{rule: "name~=(", wantErr: "invalid regular expression"},
+		{rule: `name="web`, wantErr: "unterminated string"},
+		{rule: "name==web", wantErr: "expected '=', '!=', '~=' or '!~'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			var rule ContainerRule
+			assert.ErrorContains(t, rule.Decode(tt.rule), tt.wantErr)
+		})
+	}
+}
+
+func Test_ContainerRules(t *testing.T) {
+	var rules ContainerRules
+	require.NoError(t, rules.Decode("runtime=podman; label:app=web ;"))
+	require.Len(t, rules, 2)
+
+	rule, ok := rules.Match(ContainerProperties{Runtime: "docker", Labels: map[string]string{"app": "web"}})
+	assert.True(t, ok)
+	assert.Equal(t, "label:app=web", rule.String())
+
+	_, ok = rules.Match(ContainerProperties{Runtime: "docker"})
+	assert.False(t, ok)
+}
@@ -0,0 +1,151 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package config
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// labelAttributePresets are the built-in label mappings of common orchestrators, selected by name
+var labelAttributePresets = map[string][]string{
+	"compose": {
+		"com.docker.compose.project=compose.project",
+		"com.docker.compose.service=compose.service",
+		"com.docker.compose.container-number=compose.container_number",
+	},
+	"ecs": {
+		"com.amazonaws.ecs.cluster=ecs.cluster",
+		"com.amazonaws.ecs.container-name=ecs.container.name",
+		"com.amazonaws.ecs.task-arn=ecs.task.arn",
+		"com.amazonaws.ecs.task-definition-family=ecs.task.family",
+		"com.amazonaws.ecs.task-definition-version=ecs.task.revision",
+	},
+	"nomad": {
+		"com.hashicorp.nomad.namespace=nomad.namespace",
+		"com.hashicorp.nomad.job_name=nomad.job.name",
+		"com.hashicorp.nomad.task_group_name=nomad.task_group.name",
+		"com.hashicorp.nomad.task_name=nomad.task.name",
+		"com.hashicorp.nomad.alloc_id=nomad.alloc.id",
+		"com.hashicorp.nomad.node_name=nomad.node.name",
+	},
+	"swarm": {
+		"com.docker.stack.namespace=swarm.stack",
+		"com.docker.swarm.service.name=swarm.service.name",
+		"com.docker.swarm.task.name=swarm.task.name",
+		"com.docker.swarm.node.id=swarm.node.id",
+	},
+}
+
+// LabelAttributePreset is the name of a built-in set of label mappings (compose, ecs, nomad or swarm)
+type LabelAttributePreset string
+
+func (p *LabelAttributePreset) UnmarshalText(text []byte) error {
+	return p.Decode(string(text))
+}
+
+func (p *LabelAttributePreset) Decode(value string) error {
+	if _, ok := labelAttributePresets[value]; !ok {
+		return fmt.Errorf("unknown label attribute preset %q, expected one of %s", value, strings.Join(slices.Sorted(maps.Keys(labelAttributePresets)), ", "))
+	}
+	*p = LabelAttributePreset(value)
+	return nil
+}
+
+// Rules returns the label mappings of the preset
+func (p LabelAttributePreset) Rules() []LabelAttributeRule {
+	var rules []LabelAttributeRule
+	for _, spec := range labelAttributePresets[string(p)] {
+		var rule LabelAttributeRule
+		if err := rule.Decode(spec); err != nil {
+			panic(err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// LabelAttributeRule maps container labels to a target attribute. It is written as <label>=<attribute>, where the label
+// is either
+//   - a label key, e.g. com.docker.compose.service=compose.service
+//   - a prefix ending with *, the rest of the key replaces a * in the attribute, e.g. com.acme.*=acme.*
+//   - a regular expression starting with ~, the attribute may reference its groups, e.g. ~^com\.acme\.(\w+)$=acme.$1
+type LabelAttributeRule struct {
+	p         string
+	key       string
+	prefix    string
+	re        *regexp.Regexp
+	attribute string
+}
+
+func (r LabelAttributeRule) String() string {
+	return r.p
+}
+
+func (r LabelAttributeRule) MarshalText() ([]byte, error) {
+	return []byte(r.p), nil
+}
+
+func (r *LabelAttributeRule) UnmarshalText(text []byte) error {
+	return r.Decode(string(text))
+}
+
+func (r *LabelAttributeRule) Decode(value string) error {
+	i := strings.LastIndex(value, "=")
+	if i <= 0 || i == len(value)-1 {
+		return fmt.Errorf("invalid label attribute rule %q, expected <label>=<attribute>", value)
+	}
+	label, attribute := value[:i], value[i+1:]
+
+	rule := LabelAttributeRule{p: value, attribute: attribute}
+	if expression, ok := strings.CutPrefix(label, "~"); ok {
+		re, err := regexp.Compile(expression)
+		if err != nil {
+			return fmt.Errorf("invalid label attribute rule %q: %w", value, err)
+		}
+		rule.re = re
+	} else if prefix, ok := strings.CutSuffix(label, "*"); ok {
+		rule.prefix = prefix
+	} else {
+		rule.key = label
+	}
+	*r = rule
+	return nil
+}
+
+// Map returns the attribute for the given label key, false if the rule doesn't match the key
+func (r LabelAttributeRule) Map(key string) (string, bool) {
+	switch {
+	case r.re != nil:
+		match := r.re.FindStringSubmatchIndex(key)
+		if match == nil {
+			return "", false
+		}
+		return string(r.re.ExpandString(nil, r.attribute, key, match)), true
+	case r.prefix != "":
+		rest, ok := strings.CutPrefix(key, r.prefix)
+		if !ok || rest == "" {
+			return "", false
+		}
+		return strings.Replace(r.attribute, "*", rest, 1), true
+	case key == r.key:
+		return r.attribute, true
+	default:
+		return "", false
+	}
+}
+
+// Attribute returns the attribute the rule maps to, false if it depends on the label key
+func (r LabelAttributeRule) Attribute() (string, bool) {
+	if r.re != nil && strings.Contains(r.attribute, "$") {
+		return "", false
+	}
+	if r.prefix != "" && strings.Contains(r.attribute, "*") {
+		return "", false
+	}
+	return r.attribute, true
+}
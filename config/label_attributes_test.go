@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_LabelAttributeRule(t *testing.T) {
+	tests := []struct {
+		rule          string
+		key           string
+		want          string
+		wantMatch     bool
+		wantAttribute string
+	}{
+		{rule: "com.docker.compose.service=compose.service", key: "com.docker.compose.service", want: "compose.service", wantMatch: true, wantAttribute: "compose.service"},
+		{rule: "com.docker.compose.service=compose.service", key: "com.docker.compose.project", wantAttribute: "compose.service"},
+		{rule: "com.acme.*=acme.*", key: "com.acme.team", want: "acme.team", wantMatch: true},
+		{rule: "com.acme.*=acme.*", key: "com.acme."},
+		{rule: "com.acme.*=acme.owner", key: "com.acme.team", want: "acme.owner", wantMatch: true, wantAttribute: "acme.owner"},
+		{rule: `~^com\.acme\.(\w+)\.id$=acme.$1`, key: "com.acme.team.id", want: "acme.team", wantMatch: true},
+		{rule: `~^com\.acme\.(\w+)\.id$=acme.$1`, key: "com.acme.team.name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule+" "+tt.key, func(t *testing.T) {
+			var rule LabelAttributeRule
+			require.NoError(t, rule.Decode(tt.rule))
+
+			got, ok := rule.Map(tt.key)
+			assert.Equal(t, tt.wantMatch, ok)
+			assert.Equal(t, tt.want, got)
+
+			attribute, ok := rule.Attribute()
+			assert.Equal(t, tt.wantAttribute != "", ok)
+			assert.Equal(t, tt.wantAttribute, attribute)
+		})
+	}
+}
+
+func Test_LabelAttributeRule_invalid(t *testing.T) {
+	for _, value := range []string{"com.acme", "=acme", "com.acme=", `~com\.acme(=acme`} {
+		var rule LabelAttributeRule
+		assert.Error(t, rule.Decode(value), value)
+	}
+}
+
+func Test_LabelAttributePreset(t *testing.T) {
+	var preset LabelAttributePreset
+	require.NoError(t, preset.Decode("nomad"))
+	assert.NotEmpty(t, preset.Rules())
+
+	assert.ErrorContains(t, preset.Decode("kubernetes"), "expected one of compose, ecs, nomad, swarm")
+}
@@ -37,6 +37,15 @@ type FillDiskActionState struct {
 	Sidecar         diskfill.SidecarOpts
 	FillDiskOpts    diskfill.Opts
 	IgnoreExitCodes []int
+	DryRun          bool
+}
+
+func (s *FillDiskActionState) journalRef() journalRef {
+	return journalRef{ExecutionId: s.ExecutionId, ContainerId: s.ContainerID, TargetLabel: s.TargetLabel, DryRun: s.DryRun}
+}
+
+func (s *FillDiskActionState) describeAttack(attack *ActiveAttack) {
+	attack.SidecarId = s.Sidecar.Id
 }
 
 // Make sure fillDiskAction implements all required interfaces
@@ -45,10 +54,10 @@ var _ action_kit_sdk.ActionWithStop[FillDiskActionState] = (*fillDiskAction)(nil
 var _ action_kit_sdk.ActionWithStatus[FillDiskActionState] = (*fillDiskAction)(nil)
 
 func NewFillDiskContainerAction(r ociruntime.OciRuntime, c types.Client) action_kit_sdk.Action[FillDiskActionState] {
-	return &fillDiskAction{
+	return withJournal[FillDiskActionState](&fillDiskAction{
 		ociRuntime: r,
 		client:     c,
-	}
+	})
 }
 
 func (a *fillDiskAction) NewEmptyState() FillDiskActionState {
@@ -221,6 +230,7 @@ func (a *fillDiskAction) Prepare(ctx context.Context, state *FillDiskActionState
 	}
 	state.FillDiskOpts = opts
 	state.ExecutionId = request.ExecutionId
+	state.DryRun = isDryRun(request)
 
 	if err := diskfill.CheckPathWritableRunc(ctx, a.ociRuntime, state.Sidecar, opts.TempPath); err != nil {
 		return nil, extension_kit.ToError(err.Error(), nil)
@@ -264,6 +274,14 @@ func (a *fillDiskAction) Start(ctx context.Context, state *FillDiskActionState)
 	}, nil
 }
 
+func (a *fillDiskAction) dryRunPlan(state *FillDiskActionState) ([]string, error) {
+	opts := state.FillDiskOpts
+	return []string{
+		fmt.Sprintf("run a fill disk sidecar in the namespaces of pid %d", state.Sidecar.TargetProcess.Pid),
+		fmt.Sprintf("fill %s with mode %s, size %d, block size %d and method %s", opts.TempPath, opts.Mode, opts.Size, opts.BlockSize, opts.Method),
+	}, nil
+}
+
 func (a *fillDiskAction) Status(ctx context.Context, state *FillDiskActionState) (*action_kit_api.StatusResult, error) {
 	_, err := a.fillDiskContainerExited(state.ExecutionId)
 	if err == nil {
@@ -328,6 +346,10 @@ func (a *fillDiskAction) Status(ctx context.Context, state *FillDiskActionState)
 }
 
 func (a *fillDiskAction) Stop(_ context.Context, state *FillDiskActionState) (*action_kit_api.StopResult, error) {
+	if _, ok := a.diskfills.Load(state.ExecutionId); !ok {
+		return a.deleteFillDiskSidecar(state)
+	}
+
 	if err := a.stopFillDiskContainer(state.ExecutionId); err != nil {
 		return nil, extension_kit.ToError("Failed to stop fill disk on container", err)
 	}
@@ -342,6 +364,23 @@ func (a *fillDiskAction) Stop(_ context.Context, state *FillDiskActionState) (*a
 	}, nil
 }
 
+// deleteFillDiskSidecar is used when there is no handle for the execution, as the extension was restarted in the meantime.
+func (a *fillDiskAction) deleteFillDiskSidecar(state *FillDiskActionState) (*action_kit_api.StopResult, error) {
+	ctx := context.Background() // don't use the context as the action should be stopped even if the request context is cancelled
+	if err := a.ociRuntime.Delete(ctx, state.Sidecar.Id, true); err != nil {
+		log.Debug().Err(err).Str("id", state.Sidecar.Id).Msg("no fill disk sidecar to delete")
+	}
+
+	return &action_kit_api.StopResult{
+		Messages: &[]action_kit_api.Message{
+			{
+				Level:   extutil.Ptr(action_kit_api.Warn),
+				Message: fmt.Sprintf("Removed fill disk sidecar of container %s after a restart of the extension. The fill file in %s might still exist.", state.TargetLabel, state.FillDiskOpts.TempPath),
+			},
+		},
+	}, nil
+}
+
 func (a *fillDiskAction) fillDiskContainerExited(executionId uuid.UUID) (bool, error) {
 	s, ok := a.diskfills.Load(executionId)
 	if !ok {
@@ -38,6 +38,11 @@ type FillMemoryActionState struct {
 	TargetProcess   ociruntime.LinuxProcessInfo
 	FillMemoryOpts  memfill.Opts
 	IgnoreExitCodes []int
+	DryRun          bool
+}
+
+func (s *FillMemoryActionState) journalRef() journalRef {
+	return journalRef{ExecutionId: s.ExecutionId, ContainerId: s.ContainerID, TargetLabel: s.TargetLabel, DryRun: s.DryRun}
 }
 
 // Make sure fillMemoryAction implements all required interfaces
@@ -46,10 +51,10 @@ var _ action_kit_sdk.ActionWithStop[FillMemoryActionState] = (*fillMemoryAction)
 var _ action_kit_sdk.ActionWithStatus[FillMemoryActionState] = (*fillMemoryAction)(nil)
 
 func NewFillMemoryContainerAction(r ociruntime.OciRuntime, c types.Client) action_kit_sdk.Action[FillMemoryActionState] {
-	return &fillMemoryAction{
+	return withJournal[FillMemoryActionState](&fillMemoryAction{
 		ociRuntime: r,
 		client:     c,
-	}
+	})
 }
 
 func (a *fillMemoryAction) NewEmptyState() FillMemoryActionState {
@@ -175,6 +180,7 @@ func (a *fillMemoryAction) Prepare(ctx context.Context, state *FillMemoryActionS
 	state.TargetProcess = processInfo
 	state.FillMemoryOpts = opts
 	state.ExecutionId = request.ExecutionId
+	state.DryRun = isDryRun(request)
 
 	if !extutil.ToBool(request.Config["failOnOomKill"]) {
 		state.IgnoreExitCodes = []int{137}
@@ -205,6 +211,14 @@ func (a *fillMemoryAction) Start(_ context.Context, state *FillMemoryActionState
 	}, nil
 }
 
+func (a *fillMemoryAction) dryRunPlan(state *FillMemoryActionState) ([]string, error) {
+	opts := state.FillMemoryOpts
+	return []string{
+		fmt.Sprintf("run a fill memory process in the cgroup of pid %d", state.TargetProcess.Pid),
+		fmt.Sprintf("fill %d%s of memory with mode %s for %s", opts.Size, opts.Unit, opts.Mode, opts.Duration),
+	}, nil
+}
+
 func (a *fillMemoryAction) Status(ctx context.Context, state *FillMemoryActionState) (*action_kit_api.StatusResult, error) {
 	exited, err := a.fillMemoryExited(state.ExecutionId)
 	if !exited {
@@ -283,6 +297,8 @@ func (a *fillMemoryAction) Status(ctx context.Context, state *FillMemoryActionSt
 func (a *fillMemoryAction) Stop(_ context.Context, state *FillMemoryActionState) (*action_kit_api.StopResult, error) {
 	messages := make([]action_kit_api.Message, 0)
 
+	// without a handle there is nothing left to do: the memfill process is a child of the extension and is gone after a restart
+
 	if a.stopFillMemoryContainer(state.ExecutionId) {
 		messages = append(messages, action_kit_api.Message{
 			Level:   extutil.Ptr(action_kit_api.Info),
@@ -0,0 +1,470 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+	"github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+	"golang.org/x/sync/syncmap"
+)
+
+const (
+	killModeOnce   = "once"
+	killModeRepeat = "repeat"
+)
+
+type killProcessAction struct {
+	client  types.Client
+	killers syncmap.Map //map[uuid.UUID]*processKiller
+	// stopped holds the reason of the process killers stopped by an emergency stop
+	stopped syncmap.Map //map[uuid.UUID]string
+}
+
+type KillProcessActionState struct {
+	ExecutionId uuid.UUID
+	ContainerId string
+	TargetLabel string
+	ProcessName string
+	Graceful    bool
+	Mode        string
+	Interval    time.Duration
+	DryRun      bool
+}
+
+func (s *KillProcessActionState) journalRef() journalRef {
+	return journalRef{ExecutionId: s.ExecutionId, ContainerId: s.ContainerId, TargetLabel: s.TargetLabel, DryRun: s.DryRun}
+}
+
+// Make sure killProcessAction implements all required interfaces
+var _ action_kit_sdk.Action[KillProcessActionState] = (*killProcessAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[KillProcessActionState] = (*killProcessAction)(nil)
+var _ action_kit_sdk.ActionWithStop[KillProcessActionState] = (*killProcessAction)(nil)
+
+func NewKillProcessContainerAction(client types.Client) action_kit_sdk.Action[KillProcessActionState] {
+	a := &killProcessAction{
+		client:  client,
+		killers: syncmap.Map{},
+		stopped: syncmap.Map{},
+	}
+	registerEmergencyStopper(a.Describe().Id, a)
+	return a
+}
+
+func (a *killProcessAction) NewEmptyState() KillProcessActionState {
+	return KillProcessActionState{}
+}
+
+func (a *killProcessAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          fmt.Sprintf("%s.kill_process", BaseActionID),
+		Label:       "Kill Process",
+		Description: "Kills the processes matching a name in the container once or repeatedly.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        new(killProcessIcon),
+		TargetSelection: &action_kit_api.TargetSelection{
+			TargetType:         targetID,
+			SelectionTemplates: &targetSelectionTemplates,
+		},
+		Technology:  new("Container"),
+		Category:    new("State"),
+		Kind:        action_kit_api.Attack,
+		TimeControl: action_kit_api.TimeControlExternal,
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:        "processName",
+				Label:       "Process Name",
+				Description: new("Regular expression matched against the name and command line of the processes in the container."),
+				Type:        action_kit_api.ActionParameterTypeString,
+				Required:    new(true),
+				Order:       new(0),
+			},
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  new("How long should the processes be killed and watched for respawns?"),
+				Type:         action_kit_api.ActionParameterTypeDuration,
+				DefaultValue: new("30s"),
+				Required:     new(true),
+				Order:        new(1),
+			},
+			{
+				Name:         "mode",
+				Label:        "Mode",
+				Description:  new("Kill the processes once or repeatedly at the given interval?"),
+				Type:         action_kit_api.ActionParameterTypeString,
+				DefaultValue: new(killModeOnce),
+				Required:     new(true),
+				Order:        new(2),
+				Options: new([]action_kit_api.ParameterOption{
+					action_kit_api.ExplicitParameterOption{
+						Label: "Once",
+						Value: killModeOnce,
+					},
+					action_kit_api.ExplicitParameterOption{
+						Label: "Repeatedly",
+						Value: killModeRepeat,
+					},
+				}),
+			},
+			{
+				Name:         "interval",
+				Label:        "Interval",
+				Description:  new("How often to look for (respawned) processes and, when killing repeatedly, kill them again."),
+				Type:         action_kit_api.ActionParameterTypeDuration,
+				DefaultValue: new("5s"),
+				Required:     new(true),
+				Order:        new(3),
+			},
+			{
+				Name:         "graceful",
+				Label:        "Graceful",
+				Description:  new("Kill the processes gracefully using SIGTERM or immediately using SIGKILL?"),
+				Type:         action_kit_api.ActionParameterTypeBoolean,
+				DefaultValue: new("false"),
+				Required:     new(true),
+				Order:        new(4),
+				Advanced:     new(true),
+			},
+			dryRunParameter,
+		},
+		Status: new(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: new("5s"),
+		}),
+		Stop: new(action_kit_api.MutatingEndpointReference{}),
+	}
+}
+
+func (a *killProcessAction) Prepare(ctx context.Context, state *KillProcessActionState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	container, label, err := getContainerTarget(ctx, a.client, *request.Target)
+	if err != nil {
+		return nil, extension_kit.ToError("Failed to get target container", err)
+	}
+
+	state.ExecutionId = request.ExecutionId
+	state.ContainerId = container.Id()
+	state.TargetLabel = label
+	state.ProcessName = extutil.ToString(request.Config["processName"])
+	state.Graceful = extutil.ToBool(request.Config["graceful"])
+	state.Mode = extutil.ToString(request.Config["mode"])
+	state.Interval = time.Duration(extutil.ToInt64(request.Config["interval"])) * time.Millisecond
+	state.DryRun = isDryRun(request)
+
+	if state.ProcessName == "" {
+		return nil, extension_kit.ToError("The process name is required", nil)
+	}
+	if _, err := regexp.Compile(state.ProcessName); err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Invalid process name %q", state.ProcessName), err)
+	}
+	if state.Mode != killModeOnce && state.Mode != killModeRepeat {
+		return nil, extension_kit.ToError(fmt.Sprintf("Unsupported mode %q", state.Mode), nil)
+	}
+	if state.Interval < time.Second {
+		return nil, extension_kit.ToError("The interval must be at least 1s", nil)
+	}
+	return nil, attackGuard.admit(ctx, a.Describe().Id, request)
+}
+
+func (a *killProcessAction) Start(ctx context.Context, state *KillProcessActionState) (*action_kit_api.StartResult, error) {
+	if state.DryRun {
+		signal := "SIGKILL"
+		if state.Graceful {
+			signal = "SIGTERM"
+		}
+		plan := fmt.Sprintf("kill the processes matching %q using %s once, watching for respawns every %s", state.ProcessName, signal, state.Interval)
+		if state.Mode == killModeRepeat {
+			plan = fmt.Sprintf("kill the processes matching %q using %s every %s", state.ProcessName, signal, state.Interval)
+		}
+		return dryRunStartResult(state.ExecutionId, state.TargetLabel, []string{plan}), nil
+	}
+
+	killer := newProcessKiller(a.client, state)
+
+	killed, err := killer.round(ctx)
+	if err == nil && killed == 0 {
+		err = fmt.Errorf("no process matching %q found", state.ProcessName)
+	}
+	attackStarted(a.Describe().Id, state.ExecutionId, err)
+	if err != nil {
+		killer.cancel()
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to kill processes in container %s", state.TargetLabel), err)
+	}
+
+	a.killers.Store(state.ExecutionId, killer)
+	go killer.run()
+
+	summary, _ := killer.changedSummary()
+	return &action_kit_api.StartResult{
+		Messages: new([]action_kit_api.Message{
+			{
+				Level:   extutil.Ptr(action_kit_api.Info),
+				Message: fmt.Sprintf("Killed %s in container %s", summary, state.TargetLabel),
+			},
+		}),
+	}, nil
+}
+
+func (a *killProcessAction) Status(_ context.Context, state *KillProcessActionState) (*action_kit_api.StatusResult, error) {
+	if state.DryRun {
+		return &action_kit_api.StatusResult{Completed: false}, nil
+	}
+
+	if reason, ok := a.stopped.Load(state.ExecutionId); ok {
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Error: &action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("Killing processes in container %s was stopped by the extension: %s", state.TargetLabel, reason),
+				Status: extutil.Ptr(action_kit_api.Failed),
+			},
+		}, nil
+	}
+
+	value, ok := a.killers.Load(state.ExecutionId)
+	if !ok {
+		return &action_kit_api.StatusResult{Completed: false}, nil
+	}
+
+	killer := value.(*processKiller)
+	messages := make([]action_kit_api.Message, 0, 2)
+	if err := killer.takeError(); err != nil {
+		messages = append(messages, action_kit_api.Message{
+			Level:   extutil.Ptr(action_kit_api.Warn),
+			Message: fmt.Sprintf("Failed to kill processes in container %s: %s", state.TargetLabel, err),
+		})
+	}
+	if summary, changed := killer.changedSummary(); changed {
+		messages = append(messages, action_kit_api.Message{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Killed %s in container %s", summary, state.TargetLabel),
+		})
+	}
+
+	return &action_kit_api.StatusResult{
+		Completed: false,
+		Messages:  &messages,
+	}, nil
+}
+
+func (a *killProcessAction) Stop(_ context.Context, state *KillProcessActionState) (*action_kit_api.StopResult, error) {
+	if state.DryRun {
+		return dryRunStopResult(state.TargetLabel), nil
+	}
+
+	a.stopped.Delete(state.ExecutionId)
+	value, ok := a.killers.LoadAndDelete(state.ExecutionId)
+	if !ok {
+		log.Debug().Msg("Execution run data not found, process killer was already stopped")
+		return nil, nil
+	}
+
+	killer := value.(*processKiller)
+	killer.stop()
+	attackEnded(state.ExecutionId, false)
+
+	return &action_kit_api.StopResult{
+		Messages: new([]action_kit_api.Message{
+			{
+				Level:   extutil.Ptr(action_kit_api.Info),
+				Message: fmt.Sprintf("Killed %s in container %s", killer.summary(), state.TargetLabel),
+			},
+		}),
+		Summary: &action_kit_api.Summary{
+			Level: action_kit_api.SummaryLevelInfo,
+			Text:  fmt.Sprintf("Killed %s", killer.summary()),
+		},
+	}, nil
+}
+
+func (a *killProcessAction) emergencyStop(_ context.Context, reason string) []RevertReport {
+	var reports []RevertReport
+	a.killers.Range(func(key, value any) bool {
+		executionId := key.(uuid.UUID)
+		if _, ok := a.killers.LoadAndDelete(executionId); !ok {
+			return true
+		}
+		a.stopped.Store(executionId, reason)
+
+		killer := value.(*processKiller)
+		killer.stop()
+		attackEnded(executionId, false)
+
+		report := RevertReport{
+			ExecutionId: executionId,
+			ActionId:    a.Describe().Id,
+			ContainerId: killer.containerId,
+			TargetLabel: killer.targetLabel,
+			Reverted:    true,
+			Messages:    []string{fmt.Sprintf("Stopped killing processes, killed %s in container %s", killer.summary(), killer.targetLabel)},
+		}
+		recordRevert(report)
+		reports = append(reports, report)
+		return true
+	})
+	return reports
+}
+
+// processKiller kills the processes matching a pattern and keeps track how often each process (by name) was killed and
+// respawned. A process is considered to be respawned when a process with the same name, but a pid not seen before,
+// shows up after it was killed.
+type processKiller struct {
+	client      types.Client
+	containerId string
+	targetLabel string
+	pattern     *regexp.Regexp
+	signal      syscall.Signal
+	repeat      bool
+	interval    time.Duration
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu          sync.Mutex
+	seen        map[int]bool
+	killed      map[string]int
+	respawned   map[string]int
+	err         error
+	lastSummary string
+}
+
+func newProcessKiller(client types.Client, state *KillProcessActionState) *processKiller {
+	ctx, cancel := context.WithCancel(context.Background())
+	signal := syscall.SIGKILL
+	if state.Graceful {
+		signal = syscall.SIGTERM
+	}
+	return &processKiller{
+		client:      client,
+		containerId: RemovePrefix(state.ContainerId),
+		targetLabel: state.TargetLabel,
+		pattern:     regexp.MustCompile(state.ProcessName),
+		signal:      signal,
+		repeat:      state.Mode == killModeRepeat,
+		interval:    state.Interval,
+		ctx:         ctx,
+		cancel:      cancel,
+		done:        make(chan struct{}),
+		seen:        map[int]bool{},
+		killed:      map[string]int{},
+		respawned:   map[string]int{},
+	}
+}
+
+func (k *processKiller) run() {
+	defer close(k.done)
+	ticker := time.NewTicker(k.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-k.ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := k.round(k.ctx); err != nil && k.ctx.Err() == nil {
+				log.Debug().Err(err).Str("containerId", k.containerId).Msg("failed to kill processes")
+				k.mu.Lock()
+				k.err = err
+				k.mu.Unlock()
+			}
+		}
+	}
+}
+
+// round looks up the matching processes, records respawns and kills them. Only the first round kills when the
+// processes are to be killed once, later rounds just watch for respawns.
+func (k *processKiller) round(ctx context.Context) (int, error) {
+	// the pid is looked up each time, as the container might have been restarted by killing its main process
+	pid, err := k.client.GetPid(ctx, k.containerId)
+	if err != nil {
+		return 0, err
+	}
+	processes, err := findProcessesInPidNamespace(pid, k.pattern)
+	if err != nil {
+		return 0, err
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	first := len(k.seen) == 0
+	var errs []error
+	killed := 0
+	for _, process := range processes {
+		if k.seen[process.Pid] {
+			continue
+		}
+		k.seen[process.Pid] = true
+		if k.killed[process.Comm] > 0 {
+			k.respawned[process.Comm]++
+		}
+
+		if !first && !k.repeat {
+			continue
+		}
+		if err := sendSignal(process.Pid, k.signal); errors.Is(err, syscall.ESRCH) {
+			continue
+		} else if err != nil {
+			errs = append(errs, fmt.Errorf("failed to kill process %d: %w", process.Pid, err))
+			continue
+		}
+		k.killed[process.Comm]++
+		killed++
+	}
+	return killed, errors.Join(errs...)
+}
+
+func (k *processKiller) stop() {
+	k.cancel()
+	<-k.done
+}
+
+func (k *processKiller) takeError() error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	err := k.err
+	k.err = nil
+	return err
+}
+
+// changedSummary returns the summary if it changed since the last call
+func (k *processKiller) changedSummary() (string, bool) {
+	summary := k.summary()
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if summary == k.lastSummary {
+		return summary, false
+	}
+	k.lastSummary = summary
+	return summary, true
+}
+
+func (k *processKiller) summary() string {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if len(k.killed) == 0 {
+		return "no processes"
+	}
+	parts := make([]string, 0, len(k.killed))
+	for _, name := range slices.Sorted(maps.Keys(k.killed)) {
+		parts = append(parts, fmt.Sprintf("%s %dx (respawned %dx)", name, k.killed[name], k.respawned[name]))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// respawn simulates a supervisor restarting the killed process with the given pid
+func respawn(t *testing.T, pid int, p fakeProcess) {
+	processDir := filepath.Join(procRoot, strconv.Itoa(pid))
+	require.NoError(t, os.MkdirAll(filepath.Join(processDir, "ns"), 0755))
+	require.NoError(t, os.Symlink(p.pidNs, filepath.Join(processDir, "ns", "pid")))
+	require.NoError(t, os.WriteFile(filepath.Join(processDir, "comm"), []byte(p.comm), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(processDir, "cmdline"), []byte(p.cmdline), 0644))
+}
+
+func withKilledProcesses(t *testing.T) *[]int {
+	var killed []int
+	sendSignal = func(pid int, signal syscall.Signal) error {
+		assert.Equal(t, syscall.SIGKILL, signal)
+		killed = append(killed, pid)
+		return os.RemoveAll(filepath.Join(procRoot, strconv.Itoa(pid)))
+	}
+	t.Cleanup(func() { sendSignal = syscall.Kill })
+	return &killed
+}
+
+func Test_processKiller(t *testing.T) {
+	worker := fakeProcess{pidNs: "pid:[1]", comm: "worker", cmdline: "worker\x00"}
+
+	tests := []struct {
+		name          string
+		mode          string
+		wantKilled    []int
+		wantSummaries []string
+	}{
+		{
+			name:       "once",
+			mode:       killModeOnce,
+			wantKilled: []int{101},
+			wantSummaries: []string{
+				"worker 1x (respawned 0x)",
+				"worker 1x (respawned 1x)",
+				"worker 1x (respawned 1x)",
+			},
+		},
+		{
+			name:       "repeatedly",
+			mode:       killModeRepeat,
+			wantKilled: []int{101, 102, 103},
+			wantSummaries: []string{
+				"worker 1x (respawned 0x)",
+				"worker 2x (respawned 1x)",
+				"worker 3x (respawned 2x)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withFakeProc(t, map[int]fakeProcess{
+				1:   {pidNs: "pid:[1]", comm: "s6-svscan", cmdline: "s6-svscan\x00"},
+				101: worker,
+			})
+			killed := withKilledProcesses(t)
+			client := &restartingClient{pids: map[string]int{"c1": 1}}
+			killer := newProcessKiller(client, &KillProcessActionState{ContainerId: "container://c1", ProcessName: "^worker$", Mode: tt.mode, Interval: time.Second})
+			defer killer.cancel()
+
+			pid := 101
+			for _, summary := range tt.wantSummaries {
+				_, err := killer.round(t.Context())
+				require.NoError(t, err)
+				assert.Equal(t, summary, killer.summary())
+				if (*killed)[len(*killed)-1] == pid {
+					pid++
+					respawn(t, pid, worker)
+				}
+			}
+			assert.Equal(t, tt.wantKilled, *killed)
+		})
+	}
+}
+
+func Test_killProcessAction(t *testing.T) {
+	withFakeProc(t, map[int]fakeProcess{
+		1:   {pidNs: "pid:[1]", comm: "supervisord", cmdline: "supervisord\x00"},
+		101: {pidNs: "pid:[1]", comm: "nginx", cmdline: "nginx\x00"},
+	})
+	withKilledProcesses(t)
+
+	client := &restartingClient{pids: map[string]int{"test-container": 1}}
+	client.addContainer("test-container", nil)
+	action := NewKillProcessContainerAction(client)
+	state := action.NewEmptyState()
+
+	_, err := action.Prepare(t.Context(), &state, action_kit_api.PrepareActionRequestBody{
+		ExecutionId: uuid.New(),
+		Config:      map[string]any{"processName": "nginx", "mode": "repeat", "interval": 1000, "duration": 10000},
+		Target:      &action_kit_api.Target{Attributes: map[string][]string{"container.id": {"test-container"}}},
+	})
+	require.NoError(t, err)
+
+	start, err := action.Start(t.Context(), &state)
+	require.NoError(t, err)
+	assert.Equal(t, "Killed nginx 1x (respawned 0x) in container test-container", (*start.Messages)[0].Message)
+
+	respawn(t, 102, fakeProcess{pidNs: "pid:[1]", comm: "nginx", cmdline: "nginx\x00"})
+	statusAction := action.(*killProcessAction)
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		status, err := statusAction.Status(t.Context(), &state)
+		require.NoError(c, err)
+		require.NotEmpty(c, *status.Messages)
+		assert.Contains(c, (*status.Messages)[0].Message, "nginx 2x (respawned 1x)")
+	}, 5*time.Second, 100*time.Millisecond)
+
+	stop, err := statusAction.Stop(t.Context(), &state)
+	require.NoError(t, err)
+	assert.Equal(t, "Killed nginx 2x (respawned 1x)", stop.Summary.Text)
+
+	_, err = action.Start(t.Context(), &state)
+	assert.ErrorContains(t, err, "no process matching")
+}
@@ -68,6 +68,36 @@ type NetworkActionState struct {
 	// revert error inside Stop would leak the claim for the rest of the
 	// process's life and silently shadow every future Start on this netns.
 	NetnsClaimed bool
+	// NetnsPassthrough records that Start passed through to netfault, so
+	// Stop releases the passthrough claim counted in the metrics.
+	NetnsPassthrough bool
+	DryRun           bool
+}
+
+func (s *NetworkActionState) journalRef() journalRef {
+	return journalRef{ExecutionId: s.ExecutionId, ContainerId: s.ContainerID, TargetLabel: s.TargetLabel, DryRun: s.DryRun}
+}
+
+func (s *NetworkActionState) describeAttack(attack *ActiveAttack) {
+	attack.SidecarId = s.Sidecar.Id
+	switch {
+	case s.IsShadow:
+		attack.NetnsRole = ClaimShadow.String()
+	case s.NetnsClaimed:
+		attack.NetnsRole = ClaimPrimary.String()
+	default:
+		attack.NetnsRole = ClaimPassthrough.String()
+	}
+}
+
+// adoptAfterRestart restores the netns claim of an attack started before the extension was restarted.
+func (s *NetworkActionState) adoptAfterRestart() {
+	if s.NetnsClaimed {
+		restoreNetnsClaim(netNsID(s.Sidecar.TargetProcess), s.NetworkOpts)
+	}
+	if s.NetnsPassthrough {
+		restoreNetnsPassthrough()
+	}
 }
 
 // Make sure networkAction implements all required interfaces
@@ -168,7 +198,7 @@ func (a *networkAction) Prepare(ctx context.Context, state *NetworkActionState,
 	}
 
 	if isUsingHostNetwork(processInfo.Namespaces) {
-		if config.Config.DisallowHostNetwork {
+		if config.Current().DisallowHostNetwork {
 			return &action_kit_api.PrepareResult{
 				Error: &action_kit_api.ActionKitError{
 					Title:  "Container is using host network. This is disallowed by your system administrators.",
@@ -203,6 +233,7 @@ func (a *networkAction) Prepare(ctx context.Context, state *NetworkActionState,
 
 	state.NetworkOpts = rawOpts
 	state.ExecutionId = request.ExecutionId
+	state.DryRun = isDryRun(request)
 	return &action_kit_api.PrepareResult{Messages: &messages}, nil
 }
 
@@ -212,11 +243,21 @@ func hasDisallowedK8sNamespaceLabel(labels map[string]string) bool {
 		return false
 	}
 
-	return slices.ContainsFunc(config.Config.DisallowK8sNamespaces, func(d config.DisallowedName) bool {
+	return slices.ContainsFunc(config.Current().DisallowK8sNamespaces, func(d config.DisallowedName) bool {
 		return d.Match(ns)
 	})
 }
 
+// disallowingRule returns the configured rule disallowing attacks on the container. Unlike excluded containers, these
+// containers are still discovered.
+func disallowingRule(client types.Client, container types.Container) (config.ContainerRule, bool) {
+	rules := config.Current().DisallowContainers
+	if len(rules) == 0 {
+		return config.ContainerRule{}, false
+	}
+	return rules.Match(containerProperties(client, container))
+}
+
 func isUsingHostNetwork(ns []ociruntime.LinuxNamespace) bool {
 	for _, n := range ns {
 		if n.Type == specs.NetworkNamespace {
@@ -266,6 +307,7 @@ func (a *networkAction) Start(ctx context.Context, state *NetworkActionState) (*
 		// netns has an active attack with DIFFERENT opts. Don't dedup —
 		// let netfault decide. state.NetnsClaimed stays false so Stop
 		// doesn't try to release a counter we never took.
+		state.NetnsPassthrough = true
 		log.Debug().
 			Str("containerId", state.ContainerID).
 			Str("netNs", nsID).
@@ -284,6 +326,10 @@ func (a *networkAction) Start(ctx context.Context, state *NetworkActionState) (*
 			releaseNetnsForAttack(nsID)
 			state.NetnsClaimed = false
 		}
+		if state.NetnsPassthrough {
+			releaseNetnsPassthrough()
+			state.NetnsPassthrough = false
+		}
 		var toomany *netfault.ErrTooManyTcCommands
 		if errors.As(err, &toomany) {
 			result.Messages = new(append(*result.Messages, action_kit_api.Message{
@@ -298,8 +344,27 @@ func (a *networkAction) Start(ctx context.Context, state *NetworkActionState) (*
 	return &result, nil
 }
 
-func (a *networkAction) Stop(_ context.Context, state *NetworkActionState) (*action_kit_api.StopResult, error) {
-	ctx := context.Background() // don't use the context as the action should be stopped even if the request context is cancelled
+func (a *networkAction) dryRunPlan(state *NetworkActionState) ([]string, error) {
+	opts, err := a.optsDecoder(state.NetworkOpts)
+	if err != nil {
+		return nil, extension_kit.ToError("Failed to deserialize network settings.", err)
+	}
+	plan := []string{fmt.Sprintf("apply %s in the network namespace of pid %d", opts.String(), state.Sidecar.TargetProcess.Pid)}
+	plan = append(plan, describeNetworkOpts(opts)...)
+	commands, err := networkCommands(context.Background(), state.Sidecar, opts)
+	if err != nil {
+		return nil, extension_kit.ToError("Failed to render the network commands.", err)
+	}
+	for _, cmd := range commands {
+		plan = append(plan, "run "+cmd)
+	}
+	return plan, nil
+}
+
+func (a *networkAction) Stop(ctx context.Context, state *NetworkActionState) (*action_kit_api.StopResult, error) {
+	// don't use the cancellation of the context as the action should be stopped even if the request context is cancelled,
+	// its values (e.g. the commands recorded for the audit log) are kept
+	ctx = context.WithoutCancel(ctx)
 
 	// Release the netns claim once at the end of Stop, regardless of which
 	// exit path we take. Guarded by NetnsClaimed so a passthrough Start
@@ -311,6 +376,9 @@ func (a *networkAction) Stop(_ context.Context, state *NetworkActionState) (*act
 			releaseNetnsForAttack(netNsID(state.Sidecar.TargetProcess))
 		}()
 	}
+	if state.NetnsPassthrough {
+		defer releaseNetnsPassthrough()
+	}
 
 	// Shadow actions never applied — their Start deferred to a sibling
 	// container's primary. Their Stop must not revert, or the primary's
@@ -17,13 +17,13 @@ import (
 )
 
 func NewNetworkLimitBandwidthContainerAction(r ociruntime.OciRuntime, client types.Client) action_kit_sdk.Action[NetworkActionState] {
-	return &networkAction{
+	return withJournal[NetworkActionState](&networkAction{
 		optsProvider: limitBandwidth(r),
 		optsDecoder:  limitBandwidthDecode,
 		description:  getNetworkLimitBandwidthDescription(),
 		ociRuntime:   r,
 		client:       client,
-	}
+	})
 }
 
 func getNetworkLimitBandwidthDescription() action_kit_api.ActionDescription {
@@ -17,13 +17,13 @@ import (
 )
 
 func NewNetworkBlackholeContainerAction(r ociruntime.OciRuntime, client types.Client) action_kit_sdk.Action[NetworkActionState] {
-	return &networkAction{
+	return withJournal[NetworkActionState](&networkAction{
 		optsProvider: blackhole(r),
 		optsDecoder:  blackholeDecode,
 		description:  getNetworkBlackholeDescription(),
 		ociRuntime:   r,
 		client:       client,
-	}
+	})
 }
 
 func getNetworkBlackholeDescription() action_kit_api.ActionDescription {
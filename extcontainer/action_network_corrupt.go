@@ -17,13 +17,13 @@ import (
 )
 
 func NewNetworkCorruptPackagesContainerAction(r ociruntime.OciRuntime, client types.Client) action_kit_sdk.Action[NetworkActionState] {
-	return &networkAction{
+	return withJournal[NetworkActionState](&networkAction{
 		optsProvider: corruptPackages(r),
 		optsDecoder:  corruptPackagesDecode,
 		description:  getNetworkCorruptPackagesDescription(),
 		ociRuntime:   r,
 		client:       client,
-	}
+	})
 }
 
 func getNetworkCorruptPackagesDescription() action_kit_api.ActionDescription {
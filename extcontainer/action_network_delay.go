@@ -18,13 +18,13 @@ import (
 )
 
 func NewNetworkDelayContainerAction(r ociruntime.OciRuntime, client types.Client) action_kit_sdk.Action[NetworkActionState] {
-	return &networkAction{
+	return withJournal[NetworkActionState](&networkAction{
 		optsProvider: delay(r),
 		optsDecoder:  delayDecode,
 		description:  getNetworkDelayDescription(),
 		ociRuntime:   r,
 		client:       client,
-	}
+	})
 }
 
 func getNetworkDelayDescription() action_kit_api.ActionDescription {
@@ -18,13 +18,13 @@ import (
 )
 
 func NewNetworkBlockDnsContainerAction(r ociruntime.OciRuntime, client types.Client) action_kit_sdk.Action[NetworkActionState] {
-	return &networkAction{
+	return withJournal[NetworkActionState](&networkAction{
 		optsProvider: blockDns(r),
 		optsDecoder:  blackholeDecode,
 		description:  getNetworkBlockDnsDescription(),
 		ociRuntime:   r,
 		client:       client,
-	}
+	})
 }
 
 func getNetworkBlockDnsDescription() action_kit_api.ActionDescription {
@@ -7,9 +7,11 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/rs/zerolog/log"
 	"github.com/steadybit/action-kit/go/action_kit_api/v2"
@@ -35,6 +37,16 @@ var (
 type DNSErrorInjectionState struct {
 	ExecutionId string
 	ContainerID string
+	TargetLabel string
+	DryRun      bool
+	// DryRunPlan describes the prepared injection, as dry runs don't create the dns-inject process
+	DryRunPlan []string
+}
+
+func (s *DNSErrorInjectionState) journalRef() journalRef {
+	// the execution id is only empty for states that were never prepared
+	executionId, _ := uuid.Parse(s.ExecutionId)
+	return journalRef{ExecutionId: executionId, ContainerId: s.ContainerID, TargetLabel: s.TargetLabel, DryRun: s.DryRun}
 }
 
 type dnsErrorInjectionAction struct {
@@ -43,7 +55,7 @@ type dnsErrorInjectionAction struct {
 }
 
 func NewNetworkDNSErrorInjectionAction(r ociruntime.OciRuntime, client types.Client) action_kit_sdk.Action[DNSErrorInjectionState] {
-	return &dnsErrorInjectionAction{ociRuntime: r, client: client}
+	return withJournal[DNSErrorInjectionState](&dnsErrorInjectionAction{ociRuntime: r, client: client})
 }
 
 func (a *dnsErrorInjectionAction) NewEmptyState() DNSErrorInjectionState {
@@ -81,7 +93,7 @@ func (a *dnsErrorInjectionAction) Describe() action_kit_api.ActionDescription {
 }
 
 func (a *dnsErrorInjectionAction) Prepare(ctx context.Context, state *DNSErrorInjectionState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
-	container, _, err := getContainerTarget(ctx, a.client, *request.Target)
+	container, label, err := getContainerTarget(ctx, a.client, *request.Target)
 	if err != nil {
 		return nil, err
 	}
@@ -93,7 +105,7 @@ func (a *dnsErrorInjectionAction) Prepare(ctx context.Context, state *DNSErrorIn
 	}
 
 	if isUsingHostNetwork(processInfo.Namespaces) {
-		if config.Config.DisallowHostNetwork {
+		if config.Current().DisallowHostNetwork {
 			return &action_kit_api.PrepareResult{
 				Error: &action_kit_api.ActionKitError{
 					Title:  "Container is using host network. This is disallowed by your system administrators.",
@@ -116,6 +128,15 @@ func (a *dnsErrorInjectionAction) Prepare(ctx context.Context, state *DNSErrorIn
 		return nil, err
 	}
 
+	if isDryRun(request) {
+		state.ExecutionId = request.ExecutionId.String()
+		state.ContainerID = containerId
+		state.TargetLabel = label
+		state.DryRun = true
+		state.DryRunPlan = describeDNSInjectOpts(processInfo.Pid, opts)
+		return &action_kit_api.PrepareResult{}, nil
+	}
+
 	sidecarId := fmt.Sprintf("%s-%s", request.ExecutionId.String()[24:], containerId[:min(8, len(containerId))])
 	handle, err := dnsinject.NewProcess(ctx, a.ociRuntime, processInfo, sidecarId, opts)
 	if err != nil {
@@ -124,6 +145,7 @@ func (a *dnsErrorInjectionAction) Prepare(ctx context.Context, state *DNSErrorIn
 
 	state.ExecutionId = request.ExecutionId.String()
 	state.ContainerID = containerId
+	state.TargetLabel = label
 
 	dnsInjectHandlesLock.Lock()
 	dnsInjectHandles[state.ExecutionId] = handle
@@ -145,6 +167,10 @@ func (a *dnsErrorInjectionAction) Start(_ context.Context, state *DNSErrorInject
 	return &action_kit_api.StartResult{}, nil
 }
 
+func (a *dnsErrorInjectionAction) dryRunPlan(state *DNSErrorInjectionState) ([]string, error) {
+	return state.DryRunPlan, nil
+}
+
 func (a *dnsErrorInjectionAction) Status(_ context.Context, state *DNSErrorInjectionState) (*action_kit_api.StatusResult, error) {
 	handle, ok := getDNSInjectHandle(state.ExecutionId)
 	if !ok {
@@ -180,6 +206,7 @@ func (a *dnsErrorInjectionAction) Status(_ context.Context, state *DNSErrorInjec
 func (a *dnsErrorInjectionAction) Stop(_ context.Context, state *DNSErrorInjectionState) (*action_kit_api.StopResult, error) {
 	handle, ok := getDNSInjectHandle(state.ExecutionId)
 	if !ok {
+		// also the case after a restart of the extension: the dns-inject process is a child of the extension and is gone
 		return nil, nil
 	}
 	removeDNSInjectHandle(state.ExecutionId)
@@ -304,6 +331,20 @@ func parseDNSInjectOpts(config map[string]any) (dnsinject.Opts, error) {
 	}, nil
 }
 
+func describeDNSInjectOpts(pid int, opts dnsinject.Opts) []string {
+	plan := []string{
+		fmt.Sprintf("attach the dns-inject eBPF program to the network namespace of pid %d", pid),
+		fmt.Sprintf("answer DNS queries to ports %d-%d with %v", opts.PortRange.From, opts.PortRange.To, opts.ErrorTypes),
+	}
+	for _, cidr := range opts.CIDRs {
+		plan = append(plan, fmt.Sprintf("only affect queries to %s", cidr.String()))
+	}
+	if len(opts.Hostnames) > 0 {
+		plan = append(plan, fmt.Sprintf("only affect queries for %s", strings.Join(opts.Hostnames, ", ")))
+	}
+	return plan
+}
+
 func formatDNSMetricsMessages(metrics *dnsinject.Metrics) []action_kit_api.Message {
 	markdown := fmt.Sprintf(`### Packets Processed
 - **Total Packets:** %d
@@ -17,13 +17,13 @@ import (
 )
 
 func NewNetworkPackageLossContainerAction(r ociruntime.OciRuntime, client types.Client) action_kit_sdk.Action[NetworkActionState] {
-	return &networkAction{
+	return withJournal[NetworkActionState](&networkAction{
 		optsProvider: packageLoss(r),
 		optsDecoder:  packageLossDecode,
 		description:  getNetworkPackageLossDescription(),
 		ociRuntime:   r,
 		client:       client,
-	}
+	})
 }
 
 func getNetworkPackageLossDescription() action_kit_api.ActionDescription {
@@ -0,0 +1,149 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net"
+	"slices"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_commons/network/netfault"
+	"github.com/steadybit/action-kit/go/action_kit_commons/ociruntime"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+)
+
+// targetLister lists the discovered containers, the peers of the partition are looked up in these
+type targetLister interface {
+	DiscoverTargets(ctx context.Context) ([]discovery_kit_api.Target, error)
+}
+
+func NewNetworkPartitionContainerAction(r ociruntime.OciRuntime, client types.Client, discovery targetLister) action_kit_sdk.Action[NetworkActionState] {
+	return withJournal[NetworkActionState](&networkAction{
+		optsProvider: partition(r, discovery),
+		optsDecoder:  blackholeDecode,
+		description:  getNetworkPartitionDescription(),
+		ociRuntime:   r,
+		client:       client,
+	})
+}
+
+func getNetworkPartitionDescription() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          fmt.Sprintf("%s.network_partition", BaseActionID),
+		Label:       "Partition Containers",
+		Description: "Blocks network traffic (incoming and outgoing) between the container and a group of other containers.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        new(partitionIcon),
+		TargetSelection: &action_kit_api.TargetSelection{
+			TargetType:         targetID,
+			SelectionTemplates: &targetSelectionTemplates,
+		},
+		Technology:  new("Container"),
+		Category:    new("Network"),
+		Kind:        action_kit_api.Attack,
+		TimeControl: action_kit_api.TimeControlExternal,
+		Parameters: append(
+			slices.Clone(commonNetworkParameters),
+			action_kit_api.ActionParameter{
+				Name:        "peers",
+				Label:       "Partitioned Containers",
+				Description: new("Query for the containers to partition from, e.g. k8s.container.name=\"db\" and k8s.namespace=\"shop\". Comparisons (= or !=) can be combined using and."),
+				Type:        action_kit_api.ActionParameterTypeString,
+				Required:    new(true),
+				Order:       new(1),
+			},
+		),
+	}
+}
+
+func partition(r ociruntime.OciRuntime, discovery targetLister) networkOptsProvider {
+	return func(ctx context.Context, sidecar netfault.SidecarOpts, request action_kit_api.PrepareActionRequestBody) (netfault.Opts, action_kit_api.Messages, error) {
+		query, err := parseTargetQuery(extutil.ToString(request.Config["peers"]))
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid partitioned containers query: %w", err)
+		}
+
+		// containers sharing the network namespace with the target (e.g. in the same pod) have the same ips
+		ownIps, err := readProcessIps(sidecar.TargetProcess.Pid)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		peers, ips, err := resolvePeerIps(ctx, discovery, query, request.Target.Attributes["container.id"])
+		if err != nil {
+			return nil, nil, err
+		}
+		ips = slices.DeleteFunc(ips, func(ip string) bool {
+			return slices.ContainsFunc(ownIps, func(own net.IP) bool { return own.String() == ip })
+		})
+		if len(ips) == 0 {
+			return nil, nil, fmt.Errorf("no containers with an ip address match %s", query)
+		}
+
+		// the peer ips are added to the ips given as parameter, so the partition only affects the traffic to the peers
+		config := maps.Clone(request.Config)
+		config["ip"] = append(extutil.ToStringArray(request.Config["ip"]), ips...)
+
+		filter, messages, err := mapToNetworkFilter(ctx, r, sidecar, config, getRestrictedEndpoints(request))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		messages = append(messages, action_kit_api.Message{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Partitioning from %s (%s)", strings.Join(peers, ", "), strings.Join(ips, ", ")),
+		})
+
+		return &netfault.BlackholeOpts{
+			Filter:           filter,
+			ExecutionContext: mapToExecutionContext(request),
+		}, messages, nil
+	}
+}
+
+// resolvePeerIps returns the names and ips of the discovered containers matching the query, excluding the target itself.
+// The ips are taken from the container.ip attributes of the containers.
+func resolvePeerIps(ctx context.Context, discovery targetLister, query targetQuery, self []string) ([]string, []string, error) {
+	targets, err := discovery.DiscoverTargets(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	var peers, ips []string
+	matched := false
+	for _, target := range targets {
+		if !query.matches(target.Attributes) {
+			continue
+		}
+		ids := target.Attributes["container.id"]
+		if len(ids) == 0 || slices.Contains(self, ids[0]) {
+			continue
+		}
+		matched = true
+
+		containerIps := target.Attributes["container.ip"]
+		if len(containerIps) == 0 {
+			log.Debug().Str("containerId", ids[0]).Msg("partitioned container has no ip address, ignoring it")
+			continue
+		}
+		peers = append(peers, target.Label)
+		ips = append(ips, containerIps...)
+	}
+
+	if !matched {
+		return nil, nil, fmt.Errorf("no containers discovered on the host of the target match %s, containers on other hosts can be partitioned using the Include IPs/CIDRs or Include Hostnames parameters", query)
+	}
+
+	slices.Sort(ips)
+	return peers, slices.Compact(ips), nil
+}
@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fibTrie = `Main:
+  +-- 0.0.0.0/0 3 0 5
+     |-- 0.0.0.0
+        /0 universe UNICAST
+     +-- 10.244.1.0/24 2 0 2
+        |-- 10.244.1.0
+           /24 link UNICAST
+        |-- 10.244.1.17
+           /32 host LOCAL
+        |-- 10.244.1.255
+           /32 link BROADCAST
+     +-- 127.0.0.0/8 2 0 2
+        |-- 127.0.0.1
+           /32 host LOCAL
+Local:
+  +-- 0.0.0.0/0 3 0 5
+     +-- 10.244.1.0/24 2 0 2
+        |-- 10.244.1.17
+           /32 host LOCAL
+`
+
+const ifInet6 = `00000000000000000000000000000001 01 80 10 80       lo
+fe800000000000000000000000000001 02 40 20 80     eth0
+fd000000000000000000000000000011 02 40 00 00     eth0
+`
+
+// withNetworkNamespace adds the network files for the given pid to the fake /proc
+func withNetworkNamespace(t *testing.T, pid int, fibTrie, ifInet6 string) {
+	dir := filepath.Join(procRoot, strconv.Itoa(pid))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "net"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "net", "fib_trie"), []byte(fibTrie), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "net", "if_inet6"), []byte(ifInet6), 0644))
+}
+
+func Test_readProcessIps(t *testing.T) {
+	withFakeProc(t, nil)
+	withNetworkNamespace(t, 100, fibTrie, ifInet6)
+
+	ips, err := readProcessIps(100)
+	require.NoError(t, err)
+	assert.Equal(t, []net.IP{net.ParseIP("10.244.1.17"), net.ParseIP("fd00::11")}, ips)
+}
+
+type staticTargets []discovery_kit_api.Target
+
+func (s staticTargets) DiscoverTargets(_ context.Context) ([]discovery_kit_api.Target, error) {
+	return s, nil
+}
+
+func Test_resolvePeerIps(t *testing.T) {
+	discovery := staticTargets{
+		{Label: "self", Attributes: map[string][]string{"container.id": {"containerd://self"}, "k8s.container.name": {"db"}, "container.ip": {"10.244.1.17"}}},
+		{Label: "db-1", Attributes: map[string][]string{"container.id": {"containerd://db-1"}, "k8s.container.name": {"db"}, "container.ip": {"10.244.1.18", "fd00::18"}}},
+		{Label: "db-2", Attributes: map[string][]string{"container.id": {"containerd://db-2"}, "k8s.container.name": {"db"}, "container.ip": {"10.244.1.19"}}},
+		{Label: "db-host", Attributes: map[string][]string{"container.id": {"containerd://db-host"}, "k8s.container.name": {"db"}}},
+		{Label: "web", Attributes: map[string][]string{"container.id": {"containerd://web"}, "k8s.container.name": {"web"}, "container.ip": {"10.244.1.20"}}},
+	}
+
+	query, err := parseTargetQuery(`k8s.container.name="db"`)
+	require.NoError(t, err)
+	peers, ips, err := resolvePeerIps(t.Context(), discovery, query, []string{"containerd://self"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"db-1", "db-2"}, peers)
+	assert.Equal(t, []string{"10.244.1.18", "10.244.1.19", "fd00::18"}, ips)
+
+	query, err = parseTargetQuery(`k8s.container.name="cache"`)
+	require.NoError(t, err)
+	_, _, err = resolvePeerIps(t.Context(), discovery, query, []string{"containerd://self"})
+	assert.ErrorContains(t, err, `no containers discovered on the host of the target match`)
+
+	query, err = parseTargetQuery(`container.id="containerd://self"`)
+	require.NoError(t, err)
+	_, _, err = resolvePeerIps(t.Context(), discovery, query, []string{"containerd://self"})
+	assert.Error(t, err, "the target itself is no peer")
+}
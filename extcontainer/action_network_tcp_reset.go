@@ -19,13 +19,13 @@ import (
 )
 
 func NewNetworkTcpResetContainerAction(r ociruntime.OciRuntime, client types.Client) action_kit_sdk.Action[NetworkActionState] {
-	return &networkAction{
+	return withJournal[NetworkActionState](&networkAction{
 		optsProvider: tcpReset(r),
 		optsDecoder:  tcpResetDecode,
 		description:  getNetworkTcpResetDescription(),
 		ociRuntime:   r,
 		client:       client,
-	}
+	})
 }
 
 func getNetworkTcpResetDescription() action_kit_api.ActionDescription {
@@ -287,7 +287,11 @@ func (c *MockedClient) Info(_ context.Context, id string) (types.Container, erro
 	return nil, fmt.Errorf("container not found")
 }
 
-func (c *MockedClient) Stop(_ context.Context, _ string, _ bool) error {
+func (c *MockedClient) Stop(_ context.Context, _ string, _ bool, _ time.Duration) (bool, error) {
+	panic("implement me")
+}
+
+func (c *MockedClient) Restart(_ context.Context, _ string, _ bool, _ time.Duration) error {
 	panic("implement me")
 }
 
@@ -307,6 +311,10 @@ func (c *MockedClient) GetPid(_ context.Context, _ string) (int, error) {
 	panic("implement me")
 }
 
+func (c *MockedClient) Watch(_ context.Context) (<-chan types.Event, error) {
+	panic("implement me")
+}
+
 func (c *MockedClient) Close() error {
 	panic("implement me")
 }
@@ -320,8 +328,11 @@ func (c *MockedClient) Socket() string {
 }
 
 type mockedContainer struct {
-	id     string
-	labels map[string]string
+	id        string
+	labels    map[string]string
+	networks  []types.Network
+	ports     []types.Port
+	lifecycle types.Lifecycle
 }
 
 func (m mockedContainer) Id() string {
@@ -339,3 +350,15 @@ func (m mockedContainer) ImageName() string {
 func (m mockedContainer) Labels() map[string]string {
 	return m.labels
 }
+
+func (m mockedContainer) Networks() []types.Network {
+	return m.networks
+}
+
+func (m mockedContainer) Ports() []types.Port {
+	return m.ports
+}
+
+func (m mockedContainer) Lifecycle() types.Lifecycle {
+	return m.lifecycle
+}
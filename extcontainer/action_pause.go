@@ -6,31 +6,49 @@ package extcontainer
 import (
 	"context"
 	"fmt"
+	"github.com/google/uuid"
+	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_commons/ociruntime"
 	"github.com/steadybit/action-kit/go/action_kit_sdk"
 	"github.com/steadybit/extension-container/extcontainer/container/types"
 	"github.com/steadybit/extension-kit"
 	"github.com/steadybit/extension-kit/extbuild"
 	"github.com/steadybit/extension-kit/extutil"
+	"time"
 )
 
+const freezeTimeout = 30 * time.Second
+
 type pauseAction struct {
-	client types.Client
+	client     types.Client
+	ociRuntime ociruntime.OciRuntime
 }
 
 type PauseActionState struct {
+	ExecutionId uuid.UUID
 	ContainerId string
 	TargetLabel string
+	// Runtime is the runtime of the container, which differs from the runtime of the client when using several runtimes
+	Runtime types.Runtime
+	// CGroupPath is set when the container is paused using the cgroup freezer instead of the runtime api
+	CGroupPath string
+	DryRun     bool
+}
+
+func (s *PauseActionState) journalRef() journalRef {
+	return journalRef{ExecutionId: s.ExecutionId, ContainerId: s.ContainerId, TargetLabel: s.TargetLabel, DryRun: s.DryRun}
 }
 
 // Make sure pauseAction implements all required interfaces
 var _ action_kit_sdk.Action[PauseActionState] = (*pauseAction)(nil)
 var _ action_kit_sdk.ActionWithStop[PauseActionState] = (*pauseAction)(nil)
 
-func NewPauseContainerAction(client types.Client) action_kit_sdk.Action[PauseActionState] {
-	return &pauseAction{
-		client: client,
-	}
+func NewPauseContainerAction(r ociruntime.OciRuntime, client types.Client) action_kit_sdk.Action[PauseActionState] {
+	return withJournal[PauseActionState](&pauseAction{
+		client:     client,
+		ociRuntime: r,
+	})
 }
 
 func (a *pauseAction) NewEmptyState() PauseActionState {
@@ -76,14 +94,48 @@ func (a *pauseAction) Prepare(ctx context.Context, state *PauseActionState, requ
 		return nil, extension_kit.ToError("Failed to get target container", err)
 	}
 
+	state.ExecutionId = request.ExecutionId
 	state.ContainerId = container.Id()
 	state.TargetLabel = label
+	state.Runtime = containerRuntime(a.client, container)
+	state.DryRun = isDryRun(request)
+
+	if !supportsPause(state.Runtime) {
+		processInfo, err := getProcessInfoForContainer(ctx, a.ociRuntime, RemovePrefix(state.ContainerId), specs.PIDNamespace)
+		if err != nil {
+			return nil, extension_kit.ToError("Failed to prepare pause settings.", err)
+		}
+		state.CGroupPath = processInfo.CGroupPath
+	}
 
 	return nil, nil
 }
 
+// supportsPause returns whether the runtime api can pause containers, otherwise the cgroup freezer is used.
+func supportsPause(runtime types.Runtime) bool {
+	return runtime != types.RuntimeCrio
+}
+
+func (a *pauseAction) pause(ctx context.Context, state *PauseActionState) error {
+	if state.CGroupPath != "" {
+		ctx, cancel := context.WithTimeout(ctx, freezeTimeout)
+		defer cancel()
+		return freezeCGroup(ctx, state.CGroupPath, true)
+	}
+	return a.client.Pause(ctx, RemovePrefix(state.ContainerId))
+}
+
+func (a *pauseAction) unpause(ctx context.Context, state *PauseActionState) error {
+	if state.CGroupPath != "" {
+		ctx, cancel := context.WithTimeout(ctx, freezeTimeout)
+		defer cancel()
+		return freezeCGroup(ctx, state.CGroupPath, false)
+	}
+	return a.client.Unpause(ctx, RemovePrefix(state.ContainerId))
+}
+
 func (a *pauseAction) Start(ctx context.Context, state *PauseActionState) (*action_kit_api.StartResult, error) {
-	err := a.client.Pause(ctx, RemovePrefix(state.ContainerId))
+	err := a.pause(ctx, state)
 	if err != nil {
 		return nil, extension_kit.ToError("Failed to pause container", err)
 	}
@@ -97,6 +149,13 @@ func (a *pauseAction) Start(ctx context.Context, state *PauseActionState) (*acti
 	}, nil
 }
 
+func (a *pauseAction) dryRunPlan(state *PauseActionState) ([]string, error) {
+	if state.CGroupPath != "" {
+		return []string{fmt.Sprintf("freeze the cgroup %s", state.CGroupPath)}, nil
+	}
+	return []string{fmt.Sprintf("pause the container using the %s api", state.Runtime)}, nil
+}
+
 func (a *pauseAction) Status(ctx context.Context, state *PauseActionState) (*action_kit_api.StatusResult, error) {
 	_, err := a.client.GetPid(ctx, RemovePrefix(state.ContainerId))
 	if err != nil {
@@ -115,8 +174,10 @@ func (a *pauseAction) Status(ctx context.Context, state *PauseActionState) (*act
 	}, nil
 }
 
-func (a *pauseAction) Stop(_ context.Context, state *PauseActionState) (*action_kit_api.StopResult, error) {
-	ctx := context.Background() // don't use the context as the action should be stopped even if the request context is cancelled
+func (a *pauseAction) Stop(ctx context.Context, state *PauseActionState) (*action_kit_api.StopResult, error) {
+	// don't use the cancellation of the context as the action should be stopped even if the request context is cancelled,
+	// its values (e.g. the commands recorded for the audit log) are kept
+	ctx = context.WithoutCancel(ctx)
 	_, err := a.client.GetPid(ctx, RemovePrefix(state.ContainerId))
 	if err != nil {
 		return &action_kit_api.StopResult{
@@ -129,7 +190,8 @@ func (a *pauseAction) Stop(_ context.Context, state *PauseActionState) (*action_
 		}, nil
 	}
 
-	err = a.client.Unpause(ctx, RemovePrefix(state.ContainerId))
+	// the state is all we need to unfreeze, so this also works when the extension was restarted in the meantime
+	err = a.unpause(ctx, state)
 	if err != nil {
 		return nil, extension_kit.ToError("Failed to unpause container", err)
 	}
@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// crioFirstClient spans several runtimes with CRI-O configured first, its containers belong to podman
+type crioFirstClient struct {
+	MockedClient
+}
+
+func (c *crioFirstClient) Runtime() types.Runtime {
+	return types.RuntimeCrio
+}
+
+func (c *crioFirstClient) Info(ctx context.Context, id string) (types.Container, error) {
+	container, err := c.MockedClient.Info(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return podmanContainer{container.(mockedContainer)}, nil
+}
+
+func Test_pauseAction_usesRuntimeOfContainer(t *testing.T) {
+	client := &crioFirstClient{}
+	client.addContainer("web", nil)
+	// the cgroup freezer would need the oci runtime, which isn't set
+	action := &pauseAction{client: client}
+	state := action.NewEmptyState()
+
+	_, err := action.Prepare(t.Context(), &state, action_kit_api.PrepareActionRequestBody{
+		ExecutionId: uuid.New(),
+		Target:      &action_kit_api.Target{Attributes: map[string][]string{"container.id": {"podman://web"}}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, types.RuntimePodman, state.Runtime)
+	assert.Empty(t, state.CGroupPath)
+
+	plan, err := action.dryRunPlan(&state)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pause the container using the podman api"}, plan)
+}
@@ -0,0 +1,302 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+	"github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+	"golang.org/x/sync/syncmap"
+)
+
+// restartWaitTimeout is how long to wait for the container to run again after it was stopped.
+const restartWaitTimeout = 5 * time.Minute
+
+type restartAction struct {
+	client     types.Client
+	completers syncmap.Map //map[uuid.UUID]*completer
+}
+
+type RestartActionState struct {
+	ExecutionId uuid.UUID
+	ContainerId string
+	TargetLabel string
+	Graceful    bool
+	StopTimeout time.Duration
+	// Pid is the pid of the container before the restart, the restart is completed once the container runs with another pid
+	Pid int
+	// PodUid and ContainerName identify the container in Kubernetes, where the kubelet replaces a stopped container with
+	// a new one (having a new id)
+	PodUid        string
+	ContainerName string
+	Deadline      time.Time
+	DryRun        bool
+}
+
+func (s *RestartActionState) journalRef() journalRef {
+	return journalRef{ExecutionId: s.ExecutionId, ContainerId: s.ContainerId, TargetLabel: s.TargetLabel, DryRun: s.DryRun}
+}
+
+// Make sure restartAction implements all required interfaces
+var _ action_kit_sdk.Action[RestartActionState] = (*restartAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[RestartActionState] = (*restartAction)(nil)
+var _ action_kit_sdk.ActionWithStop[RestartActionState] = (*restartAction)(nil)
+
+func NewRestartContainerAction(client types.Client) action_kit_sdk.Action[RestartActionState] {
+	return &restartAction{
+		client:     client,
+		completers: syncmap.Map{},
+	}
+}
+
+func (a *restartAction) NewEmptyState() RestartActionState {
+	return RestartActionState{}
+}
+
+func (a *restartAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          fmt.Sprintf("%s.restart", BaseActionID),
+		Label:       "Restart Container",
+		Description: "Restarts the container in place and waits until it is running again.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        new(restartIcon),
+		TargetSelection: &action_kit_api.TargetSelection{
+			TargetType:         targetID,
+			SelectionTemplates: &targetSelectionTemplates,
+		},
+		Technology:  new("Container"),
+		Category:    new("State"),
+		Kind:        action_kit_api.Attack,
+		TimeControl: action_kit_api.TimeControlInternal,
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "graceful",
+				Label:        "Graceful",
+				Description:  new("Stop the container gracefully using SIGTERM or kill it immediately using SIGKILL before starting it again?"),
+				Type:         action_kit_api.ActionParameterTypeBoolean,
+				DefaultValue: new("true"),
+				Required:     new(true),
+				Order:        new(0),
+			},
+			{
+				Name:         "stopTimeout",
+				Label:        "Stop Timeout",
+				Description:  new("How long to wait for the container to stop gracefully before it is killed?"),
+				Type:         action_kit_api.ActionParameterTypeDuration,
+				DefaultValue: new("10s"),
+				Required:     new(true),
+				Order:        new(1),
+			},
+			dryRunParameter,
+		},
+		Status: new(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: new("1s"),
+		}),
+		Stop: new(action_kit_api.MutatingEndpointReference{}),
+	}
+}
+
+func (a *restartAction) Prepare(ctx context.Context, state *RestartActionState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	container, label, err := getContainerTarget(ctx, a.client, *request.Target)
+	if err != nil {
+		return nil, extension_kit.ToError("Failed to get target container", err)
+	}
+
+	pid, err := a.client.GetPid(ctx, RemovePrefix(container.Id()))
+	if err != nil {
+		return nil, extension_kit.ToError("Failed to get pid of target container", err)
+	}
+
+	state.ExecutionId = request.ExecutionId
+	state.ContainerId = container.Id()
+	state.TargetLabel = label
+	state.Graceful = extutil.ToBool(request.Config["graceful"])
+	state.StopTimeout = time.Duration(extutil.ToInt64(request.Config["stopTimeout"])) * time.Millisecond
+	state.Pid = pid
+	state.PodUid = container.Labels()["io.kubernetes.pod.uid"]
+	state.ContainerName = container.Labels()["io.kubernetes.container.name"]
+	state.DryRun = isDryRun(request)
+	return nil, attackGuard.admit(ctx, a.Describe().Id, request)
+}
+
+func (a *restartAction) Start(_ context.Context, state *RestartActionState) (*action_kit_api.StartResult, error) {
+	if state.DryRun {
+		plan := "kill the container"
+		if state.Graceful {
+			plan = fmt.Sprintf("stop the container gracefully, killing it after the timeout of %s", state.StopTimeout)
+		}
+		return dryRunStartResult(state.ExecutionId, state.TargetLabel, []string{
+			plan,
+			fmt.Sprintf("start the container again and wait up to %s for it to run with a pid other than %d", state.StopTimeout+restartWaitTimeout, state.Pid),
+		}), nil
+	}
+
+	state.Deadline = time.Now().Add(state.StopTimeout + restartWaitTimeout)
+
+	err := a.restartContainer(state)
+	attackStarted(a.Describe().Id, state.ExecutionId, err)
+	if err != nil {
+		return nil, extension_kit.ToError("Failed to restart container", err)
+	}
+
+	return &action_kit_api.StartResult{
+		Messages: new([]action_kit_api.Message{
+			{
+				Level:   extutil.Ptr(action_kit_api.Info),
+				Message: fmt.Sprintf("Restarting container %s (graceful=%t)", state.TargetLabel, state.Graceful),
+			},
+		}),
+	}, nil
+}
+
+func (a *restartAction) Status(ctx context.Context, state *RestartActionState) (*action_kit_api.StatusResult, error) {
+	if state.DryRun {
+		return &action_kit_api.StatusResult{Completed: true}, nil
+	}
+
+	restarted, err := a.isRestartCompleted(state.ExecutionId)
+	if err != nil {
+		attackEnded(state.ExecutionId, true)
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Error: &action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("Failed to restart container %s: %s", state.TargetLabel, err),
+				Status: extutil.Ptr(action_kit_api.Failed),
+			},
+		}, nil
+	}
+
+	if restarted {
+		if message, running := a.isRunningAgain(ctx, state); running {
+			attackEnded(state.ExecutionId, false)
+			return &action_kit_api.StatusResult{
+				Completed: true,
+				Messages: new([]action_kit_api.Message{
+					{
+						Level:   extutil.Ptr(action_kit_api.Info),
+						Message: message,
+					},
+				}),
+			}, nil
+		}
+	}
+
+	if time.Now().After(state.Deadline) {
+		attackEnded(state.ExecutionId, true)
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Error: &action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("Container %s is not running again after %s", state.TargetLabel, state.StopTimeout+restartWaitTimeout),
+				Status: extutil.Ptr(action_kit_api.Failed),
+			},
+		}, nil
+	}
+
+	return &action_kit_api.StatusResult{Completed: false}, nil
+}
+
+// isRunningAgain checks if the container runs with a new pid or, for Kubernetes, if the kubelet started a new container
+// for it.
+func (a *restartAction) isRunningAgain(ctx context.Context, state *RestartActionState) (string, bool) {
+	if pid, err := a.client.GetPid(ctx, RemovePrefix(state.ContainerId)); err == nil && pid > 0 && pid != state.Pid {
+		return fmt.Sprintf("Container %s is running again with pid %d", state.TargetLabel, pid), true
+	}
+
+	if state.PodUid == "" || state.ContainerName == "" {
+		return "", false
+	}
+
+	containers, err := a.client.List(ctx)
+	if err != nil {
+		log.Debug().Err(err).Msg("Failed to list containers to find the restarted container.")
+		return "", false
+	}
+	for _, container := range containers {
+		labels := container.Labels()
+		if container.Id() == RemovePrefix(state.ContainerId) || labels["io.kubernetes.pod.uid"] != state.PodUid || labels["io.kubernetes.container.name"] != state.ContainerName {
+			continue
+		}
+		if pid, err := a.client.GetPid(ctx, container.Id()); err == nil && pid > 0 {
+			return fmt.Sprintf("Container %s was replaced by container %s", state.TargetLabel, container.Id()), true
+		}
+	}
+	return "", false
+}
+
+func (a *restartAction) Stop(_ context.Context, state *RestartActionState) (*action_kit_api.StopResult, error) {
+	if state.DryRun {
+		return dryRunStopResult(state.TargetLabel), nil
+	}
+
+	messages := make([]action_kit_api.Message, 0)
+
+	if a.cancelRestart(state.ExecutionId) {
+		messages = append(messages, action_kit_api.Message{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Canceled restart of container %s", state.TargetLabel),
+		})
+	}
+	attackEnded(state.ExecutionId, false)
+
+	return &action_kit_api.StopResult{
+		Messages: &messages,
+	}, nil
+}
+
+func (a *restartAction) restartContainer(state *RestartActionState) error {
+	// restarting gracefully takes up to the stop timeout, so it is done in a separate go routine and the status checks
+	// for completion (same as the stop action)
+	errorChannel := make(chan error, 1)
+	restartCtx, restartCancel := context.WithCancel(context.Background())
+
+	a.completers.Store(state.ExecutionId, &completer{
+		err:    errorChannel,
+		cancel: restartCancel,
+	})
+	go func() {
+		errorChannel <- a.client.Restart(restartCtx, RemovePrefix(state.ContainerId), state.Graceful, state.StopTimeout)
+		close(errorChannel)
+	}()
+
+	select {
+	case err := <-errorChannel:
+		a.completers.Delete(state.ExecutionId)
+		return err
+	case <-time.After(1 * time.Second):
+		return nil
+	}
+}
+
+func (a *restartAction) isRestartCompleted(executionId uuid.UUID) (bool, error) {
+	running, ok := a.completers.Load(executionId)
+	if !ok {
+		return true, nil
+	}
+
+	select {
+	case err := <-running.(*completer).err:
+		a.completers.Delete(executionId)
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func (a *restartAction) cancelRestart(executionId uuid.UUID) bool {
+	running, ok := a.completers.LoadAndDelete(executionId)
+	if !ok {
+		return false
+	}
+	running.(*completer).cancel()
+	return true
+}
@@ -0,0 +1,139 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type restartingClient struct {
+	MockedClient
+	mu         sync.Mutex
+	pids       map[string]int
+	restartErr error
+}
+
+func (c *restartingClient) setPid(id string, pid int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pids[id] = pid
+}
+
+func (c *restartingClient) Restart(_ context.Context, _ string, _ bool, _ time.Duration) error {
+	return c.restartErr
+}
+
+func (c *restartingClient) GetPid(_ context.Context, id string) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if pid, ok := c.pids[id]; ok {
+		return pid, nil
+	}
+	return 0, errors.New("container not running")
+}
+
+func (c *restartingClient) List(_ context.Context) ([]types.Container, error) {
+	result := make([]types.Container, 0, len(c.c))
+	for _, container := range c.c {
+		result = append(result, container)
+	}
+	return result, nil
+}
+
+func Test_restartAction(t *testing.T) {
+	k8sLabels := map[string]string{"io.kubernetes.pod.uid": "pod-1", "io.kubernetes.container.name": "app"}
+
+	tests := []struct {
+		name        string
+		state       RestartActionState
+		client      func() *restartingClient
+		wantError   string
+		wantMessage string
+	}{
+		{
+			name:  "running with new pid",
+			state: RestartActionState{ContainerId: "container://c1", Pid: 10},
+			client: func() *restartingClient {
+				client := &restartingClient{pids: map[string]int{"c1": 10}}
+				go func() {
+					time.Sleep(100 * time.Millisecond)
+					client.setPid("c1", 11)
+				}()
+				return client
+			},
+			wantMessage: "running again with pid 11",
+		},
+		{
+			name:  "replaced by kubelet",
+			state: RestartActionState{ContainerId: "containerd://c1", Pid: 10, PodUid: "pod-1", ContainerName: "app"},
+			client: func() *restartingClient {
+				client := &restartingClient{pids: map[string]int{"c2": 12}}
+				client.addContainer("c1", k8sLabels).addContainer("c2", k8sLabels)
+				return client
+			},
+			wantMessage: "replaced by container c2",
+		},
+		{
+			name:  "restart failed",
+			state: RestartActionState{ContainerId: "c1", Pid: 10},
+			client: func() *restartingClient {
+				return &restartingClient{pids: map[string]int{"c1": 10}, restartErr: errors.New("no such container")}
+			},
+			wantError: "no such container",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action := NewRestartContainerAction(tt.client()).(*restartAction)
+			state := tt.state
+			state.ExecutionId = uuid.New()
+			state.TargetLabel = "test"
+
+			_, err := action.Start(t.Context(), &state)
+			if tt.wantError != "" {
+				require.ErrorContains(t, err, tt.wantError)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.EventuallyWithT(t, func(c *assert.CollectT) {
+				result, err := action.Status(t.Context(), &state)
+				require.NoError(c, err)
+				require.True(c, result.Completed)
+				require.Nil(c, result.Error)
+				require.NotNil(c, result.Messages)
+				assert.Contains(c, (*result.Messages)[0].Message, tt.wantMessage)
+			}, 5*time.Second, 50*time.Millisecond)
+		})
+	}
+}
+
+func Test_restartAction_deadline(t *testing.T) {
+	action := NewRestartContainerAction(&restartingClient{pids: map[string]int{"c1": 10}}).(*restartAction)
+	state := RestartActionState{ExecutionId: uuid.New(), ContainerId: "c1", TargetLabel: "test", Pid: 10}
+
+	_, err := action.Start(t.Context(), &state)
+	require.NoError(t, err)
+
+	result, err := action.Status(t.Context(), &state)
+	require.NoError(t, err)
+	assert.False(t, result.Completed)
+
+	state.Deadline = time.Now().Add(-time.Second)
+	result, err = action.Status(t.Context(), &state)
+	require.NoError(t, err)
+	assert.True(t, result.Completed)
+	require.NotNil(t, result.Error)
+	assert.Contains(t, result.Error.Title, "is not running again")
+}
@@ -0,0 +1,235 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"syscall"
+
+	"github.com/google/uuid"
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_commons/ociruntime"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+	"github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+)
+
+const (
+	signalTargetMainProcess = "main"
+	signalTargetByName      = "name"
+)
+
+var signals = map[string]syscall.Signal{
+	"SIGHUP":  syscall.SIGHUP,
+	"SIGINT":  syscall.SIGINT,
+	"SIGQUIT": syscall.SIGQUIT,
+	"SIGKILL": syscall.SIGKILL,
+	"SIGUSR1": syscall.SIGUSR1,
+	"SIGUSR2": syscall.SIGUSR2,
+	"SIGTERM": syscall.SIGTERM,
+	"SIGCONT": syscall.SIGCONT,
+	"SIGSTOP": syscall.SIGSTOP,
+}
+
+// sendSignal is a variable to be replaced in tests
+var sendSignal = syscall.Kill
+
+type signalAction struct {
+	client     types.Client
+	ociRuntime ociruntime.OciRuntime
+}
+
+type SignalActionState struct {
+	ExecutionId uuid.UUID
+	ContainerId string
+	TargetLabel string
+	Signal      string
+	Target      string
+	ProcessName string
+	DryRun      bool
+}
+
+func (s *SignalActionState) journalRef() journalRef {
+	return journalRef{ExecutionId: s.ExecutionId, ContainerId: s.ContainerId, TargetLabel: s.TargetLabel, DryRun: s.DryRun}
+}
+
+// Make sure signalAction implements all required interfaces
+var _ action_kit_sdk.Action[SignalActionState] = (*signalAction)(nil)
+
+func NewSignalContainerAction(r ociruntime.OciRuntime, client types.Client) action_kit_sdk.Action[SignalActionState] {
+	return &signalAction{
+		client:     client,
+		ociRuntime: r,
+	}
+}
+
+func (a *signalAction) NewEmptyState() SignalActionState {
+	return SignalActionState{}
+}
+
+func (a *signalAction) Describe() action_kit_api.ActionDescription {
+	signalOptions := make([]action_kit_api.ParameterOption, 0, len(signals))
+	for _, name := range []string{"SIGHUP", "SIGINT", "SIGQUIT", "SIGKILL", "SIGUSR1", "SIGUSR2", "SIGTERM", "SIGSTOP", "SIGCONT"} {
+		signalOptions = append(signalOptions, action_kit_api.ExplicitParameterOption{Label: name, Value: name})
+	}
+
+	return action_kit_api.ActionDescription{
+		Id:          fmt.Sprintf("%s.signal", BaseActionID),
+		Label:       "Signal Container",
+		Description: "Sends a signal to the main process or to processes matching a name in the container.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        new(signalIcon),
+		TargetSelection: &action_kit_api.TargetSelection{
+			TargetType:         targetID,
+			SelectionTemplates: &targetSelectionTemplates,
+		},
+		Technology:  new("Container"),
+		Category:    new("State"),
+		Kind:        action_kit_api.Attack,
+		TimeControl: action_kit_api.TimeControlInstantaneous,
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "signal",
+				Label:        "Signal",
+				Description:  new("Which signal should be sent?"),
+				Type:         action_kit_api.ActionParameterTypeString,
+				DefaultValue: new("SIGHUP"),
+				Required:     new(true),
+				Order:        new(0),
+				Options:      &signalOptions,
+			},
+			{
+				Name:         "target",
+				Label:        "Target Processes",
+				Description:  new("Send the signal to the main process (pid 1) of the container or to all processes matching the process name?"),
+				Type:         action_kit_api.ActionParameterTypeString,
+				DefaultValue: new(signalTargetMainProcess),
+				Required:     new(true),
+				Order:        new(1),
+				Options: new([]action_kit_api.ParameterOption{
+					action_kit_api.ExplicitParameterOption{
+						Label: "Main process (pid 1)",
+						Value: signalTargetMainProcess,
+					},
+					action_kit_api.ExplicitParameterOption{
+						Label: "Processes matching name",
+						Value: signalTargetByName,
+					},
+				}),
+			},
+			{
+				Name:         "processName",
+				Label:        "Process Name",
+				Description:  new("Regular expression matched against the name and command line of the processes in the container. Only used when sending the signal to processes matching the name."),
+				Type:         action_kit_api.ActionParameterTypeString,
+				DefaultValue: new(""),
+				Required:     new(false),
+				Order:        new(2),
+			},
+			dryRunParameter,
+		},
+	}
+}
+
+func (a *signalAction) Prepare(ctx context.Context, state *SignalActionState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	container, label, err := getContainerTarget(ctx, a.client, *request.Target)
+	if err != nil {
+		return nil, extension_kit.ToError("Failed to get target container", err)
+	}
+
+	state.ExecutionId = request.ExecutionId
+	state.ContainerId = container.Id()
+	state.TargetLabel = label
+	state.Signal = strings.ToUpper(extutil.ToString(request.Config["signal"]))
+	state.Target = extutil.ToString(request.Config["target"])
+	state.ProcessName = extutil.ToString(request.Config["processName"])
+	state.DryRun = isDryRun(request)
+
+	if _, ok := signals[state.Signal]; !ok {
+		return nil, extension_kit.ToError(fmt.Sprintf("Unsupported signal %q", state.Signal), nil)
+	}
+	switch state.Target {
+	case signalTargetMainProcess:
+	case signalTargetByName:
+		if state.ProcessName == "" {
+			return nil, extension_kit.ToError("The process name is required to send the signal to processes matching the name", nil)
+		}
+		if _, err := regexp.Compile(state.ProcessName); err != nil {
+			return nil, extension_kit.ToError(fmt.Sprintf("Invalid process name %q", state.ProcessName), err)
+		}
+	default:
+		return nil, extension_kit.ToError(fmt.Sprintf("Unsupported target processes %q", state.Target), nil)
+	}
+	return nil, attackGuard.admit(ctx, a.Describe().Id, request)
+}
+
+func (a *signalAction) Start(ctx context.Context, state *SignalActionState) (*action_kit_api.StartResult, error) {
+	if state.DryRun {
+		plan := fmt.Sprintf("send %s to the main process of the container", state.Signal)
+		if state.Target == signalTargetByName {
+			plan = fmt.Sprintf("send %s to the processes matching %q in the container", state.Signal, state.ProcessName)
+		}
+		return dryRunStartResult(state.ExecutionId, state.TargetLabel, []string{plan}), nil
+	}
+
+	messages, err := a.signal(ctx, state)
+	attackStarted(a.Describe().Id, state.ExecutionId, err)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to send %s to container %s", state.Signal, state.TargetLabel), err)
+	}
+	attackEnded(state.ExecutionId, false)
+
+	return &action_kit_api.StartResult{
+		Messages: &messages,
+	}, nil
+}
+
+func (a *signalAction) signal(ctx context.Context, state *SignalActionState) ([]action_kit_api.Message, error) {
+	processInfo, err := getProcessInfoForContainer(ctx, a.ociRuntime, RemovePrefix(state.ContainerId), specs.PIDNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	processes := []containerProcess{{Pid: processInfo.Pid}}
+	if state.Target == signalTargetByName {
+		processes, err = findProcessesInPidNamespace(processInfo.Pid, regexp.MustCompile(state.ProcessName))
+		if err != nil {
+			return nil, err
+		}
+		if len(processes) == 0 {
+			return nil, fmt.Errorf("no process matching %q found", state.ProcessName)
+		}
+	}
+
+	var errs []error
+	messages := make([]action_kit_api.Message, 0, len(processes))
+	for _, process := range processes {
+		if err := sendSignal(process.Pid, signals[state.Signal]); errors.Is(err, syscall.ESRCH) && state.Target == signalTargetByName {
+			// the process exited after it was looked up
+			continue
+		} else if err != nil {
+			errs = append(errs, fmt.Errorf("failed to send %s to process %d: %w", state.Signal, process.Pid, err))
+			continue
+		}
+		messages = append(messages, action_kit_api.Message{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Sent %s to %s in container %s", state.Signal, describeProcess(process), state.TargetLabel),
+		})
+	}
+	return messages, errors.Join(errs...)
+}
+
+func describeProcess(process containerProcess) string {
+	if process.Comm == "" {
+		return fmt.Sprintf("process %d", process.Pid)
+	}
+	return fmt.Sprintf("process %d (%s)", process.Pid, process.Comm)
+}
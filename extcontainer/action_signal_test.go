@@ -0,0 +1,146 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"syscall"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_commons/ociruntime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func withFakeProc(t *testing.T, processes map[int]fakeProcess) {
+	dir := t.TempDir()
+	for pid, p := range processes {
+		processDir := filepath.Join(dir, strconv.Itoa(pid))
+		require.NoError(t, os.MkdirAll(filepath.Join(processDir, "ns"), 0755))
+		require.NoError(t, os.Symlink(p.pidNs, filepath.Join(processDir, "ns", "pid")))
+		require.NoError(t, os.WriteFile(filepath.Join(processDir, "comm"), []byte(p.comm+"\n"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(processDir, "cmdline"), []byte(p.cmdline), 0644))
+	}
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "self"), 0755))
+
+	procRoot = dir
+	t.Cleanup(func() { procRoot = "/proc" })
+}
+
+type fakeProcess struct {
+	pidNs   string
+	comm    string
+	cmdline string
+}
+
+func Test_findProcessesInPidNamespace(t *testing.T) {
+	withFakeProc(t, map[int]fakeProcess{
+		100: {pidNs: "pid:[1]", comm: "java", cmdline: "java\x00-jar\x00app.jar\x00"},
+		101: {pidNs: "pid:[1]", comm: "sh", cmdline: "sh\x00-c\x00sleep 10\x00"},
+		102: {pidNs: "pid:[1]", comm: "worker", cmdline: "java\x00-cp\x00worker.jar\x00"},
+		200: {pidNs: "pid:[2]", comm: "java", cmdline: "java\x00-jar\x00other.jar\x00"},
+	})
+
+	processes, err := findProcessesInPidNamespace(100, regexp.MustCompile("^java"))
+	require.NoError(t, err)
+	assert.Equal(t, []containerProcess{
+		{Pid: 100, Comm: "java", Cmdline: "java -jar app.jar"},
+		{Pid: 102, Comm: "worker", Cmdline: "java -cp worker.jar"},
+	}, processes)
+
+	processes, err = findProcessesInPidNamespace(100, regexp.MustCompile("nginx"))
+	require.NoError(t, err)
+	assert.Empty(t, processes)
+
+	_, err = findProcessesInPidNamespace(999, regexp.MustCompile("java"))
+	assert.Error(t, err)
+}
+
+func Test_signalAction(t *testing.T) {
+	withFakeProc(t, map[int]fakeProcess{
+		100: {pidNs: "pid:[1]", comm: "nginx", cmdline: "nginx: master process\x00"},
+		101: {pidNs: "pid:[1]", comm: "nginx", cmdline: "nginx: worker process\x00"},
+		102: {pidNs: "pid:[1]", comm: "nginx", cmdline: "nginx: worker process\x00"},
+	})
+	getProcessInfoForContainer = func(_ context.Context, _ ociruntime.OciRuntime, _ string, _ ...specs.LinuxNamespaceType) (ociruntime.LinuxProcessInfo, error) {
+		return ociruntime.LinuxProcessInfo{Pid: 100}, nil
+	}
+	defer func() {
+		getProcessInfoForContainer = getProcessInfoForContainerImpl
+		sendSignal = syscall.Kill
+	}()
+
+	var sent map[int]syscall.Signal
+	sendSignal = func(pid int, signal syscall.Signal) error {
+		if pid == 102 {
+			return syscall.ESRCH
+		}
+		sent[pid] = signal
+		return nil
+	}
+
+	tests := []struct {
+		name      string
+		config    map[string]any
+		wantSent  map[int]syscall.Signal
+		wantError string
+	}{
+		{
+			name:     "main process",
+			config:   map[string]any{"signal": "SIGHUP", "target": "main"},
+			wantSent: map[int]syscall.Signal{100: syscall.SIGHUP},
+		},
+		{
+			name:     "processes matching name",
+			config:   map[string]any{"signal": "SIGQUIT", "target": "name", "processName": "worker"},
+			wantSent: map[int]syscall.Signal{101: syscall.SIGQUIT},
+		},
+		{
+			name:      "no process matching",
+			config:    map[string]any{"signal": "SIGUSR1", "target": "name", "processName": "java"},
+			wantError: "no process matching",
+		},
+		{
+			name:      "unsupported signal",
+			config:    map[string]any{"signal": "SIGWINCH", "target": "main"},
+			wantError: "Unsupported signal",
+		},
+		{
+			name:      "missing process name",
+			config:    map[string]any{"signal": "SIGHUP", "target": "name"},
+			wantError: "process name is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sent = map[int]syscall.Signal{}
+			action := NewSignalContainerAction(newMockedRunc(), newMockedContainerClient().addContainer("test-container", nil))
+			state := action.NewEmptyState()
+
+			_, err := action.Prepare(t.Context(), &state, action_kit_api.PrepareActionRequestBody{
+				ExecutionId: uuid.New(),
+				Config:      tt.config,
+				Target:      &action_kit_api.Target{Attributes: map[string][]string{"container.id": {"test-container"}}},
+			})
+			if err == nil {
+				_, err = action.Start(t.Context(), &state)
+			}
+
+			if tt.wantError != "" {
+				require.ErrorContains(t, err, tt.wantError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSent, sent)
+		})
+	}
+}
@@ -17,6 +17,9 @@ import (
 	"time"
 )
 
+// defaultGracePeriod is used for experiments created before the grace period was configurable
+const defaultGracePeriod = 10 * time.Second
+
 type stopAction struct {
 	client     types.Client
 	completers syncmap.Map //map[uuid.UUID]*completer
@@ -25,13 +28,21 @@ type stopAction struct {
 type completer struct {
 	err    <-chan error
 	cancel context.CancelFunc
+	// killed is set before the error is sent, so it may only be read after receiving from err
+	killed bool
 }
 
 type StopActionState struct {
 	ContainerId string
 	TargetLabel string
 	Graceful    bool
+	GracePeriod time.Duration
 	ExecutionId uuid.UUID
+	DryRun      bool
+}
+
+func (s *StopActionState) journalRef() journalRef {
+	return journalRef{ExecutionId: s.ExecutionId, ContainerId: s.ContainerId, TargetLabel: s.TargetLabel, DryRun: s.DryRun}
 }
 
 // Make sure stopAction implements all required interfaces
@@ -75,6 +86,16 @@ func (a *stopAction) Describe() action_kit_api.ActionDescription {
 				Required:     new(true),
 				Order:        new(0),
 			},
+			{
+				Name:         "gracePeriod",
+				Label:        "Grace Period",
+				Description:  new("How long to wait for the container to stop gracefully before it is killed?"),
+				Type:         action_kit_api.ActionParameterTypeDuration,
+				DefaultValue: new("10s"),
+				Required:     new(true),
+				Order:        new(1),
+			},
+			dryRunParameter,
 		},
 		Status: new(action_kit_api.MutatingEndpointReferenceWithCallInterval{
 			CallInterval: new("1s"),
@@ -93,12 +114,26 @@ func (a *stopAction) Prepare(ctx context.Context, state *StopActionState, reques
 	state.TargetLabel = label
 
 	state.Graceful = extutil.ToBool(request.Config["graceful"])
+	state.GracePeriod = time.Duration(extutil.ToInt64(request.Config["gracePeriod"])) * time.Millisecond
+	if state.GracePeriod <= 0 {
+		state.GracePeriod = defaultGracePeriod
+	}
 	state.ExecutionId = request.ExecutionId
-	return nil, nil
+	state.DryRun = isDryRun(request)
+	return nil, attackGuard.admit(ctx, a.Describe().Id, request)
 }
 
 func (a *stopAction) Start(_ context.Context, state *StopActionState) (*action_kit_api.StartResult, error) {
-	err := a.stopContainer(state.ExecutionId, RemovePrefix(state.ContainerId), state.Graceful)
+	if state.DryRun {
+		plan := "kill the container"
+		if state.Graceful {
+			plan = fmt.Sprintf("stop the container gracefully, killing it after the grace period of %s", state.GracePeriod)
+		}
+		return dryRunStartResult(state.ExecutionId, state.TargetLabel, []string{plan}), nil
+	}
+
+	err := a.stopContainer(state.ExecutionId, RemovePrefix(state.ContainerId), state.Graceful, state.GracePeriod)
+	attackStarted(a.Describe().Id, state.ExecutionId, err)
 	if err != nil {
 		return nil, extension_kit.ToError("Failed to stop container", err)
 	}
@@ -114,19 +149,36 @@ func (a *stopAction) Start(_ context.Context, state *StopActionState) (*action_k
 }
 
 func (a *stopAction) Status(_ context.Context, state *StopActionState) (*action_kit_api.StatusResult, error) {
+	if state.DryRun {
+		return &action_kit_api.StatusResult{Completed: true}, nil
+	}
+
 	var messages []action_kit_api.Message
-	completed, err := a.isStopContainerCompleted(state.ExecutionId)
+	completed, killed, err := a.isStopContainerCompleted(state.ExecutionId)
 	if err != nil {
 		messages = append(messages, action_kit_api.Message{
 			Level:   extutil.Ptr(action_kit_api.Error),
 			Message: fmt.Sprintf("Failed to stop container %s: %s", state.TargetLabel, err),
 		})
+	} else if completed && killed && state.Graceful {
+		messages = append(messages, action_kit_api.Message{
+			Level:   extutil.Ptr(action_kit_api.Warn),
+			Message: fmt.Sprintf("Container %s exited with SIGKILL (exit code 137), it was killed after the grace period of %s or by the OOM killer", state.TargetLabel, state.GracePeriod),
+		})
+	} else if completed && killed {
+		messages = append(messages, action_kit_api.Message{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Container %s killed", state.TargetLabel),
+		})
 	} else if completed {
 		messages = append(messages, action_kit_api.Message{
 			Level:   extutil.Ptr(action_kit_api.Info),
-			Message: fmt.Sprintf("Container %s stopped", state.TargetLabel),
+			Message: fmt.Sprintf("Container %s exited within the grace period of %s", state.TargetLabel, state.GracePeriod),
 		})
 	}
+	if completed {
+		attackEnded(state.ExecutionId, err != nil)
+	}
 
 	return &action_kit_api.StatusResult{
 		Completed: completed,
@@ -135,9 +187,14 @@ func (a *stopAction) Status(_ context.Context, state *StopActionState) (*action_
 }
 
 func (a *stopAction) Stop(_ context.Context, state *StopActionState) (*action_kit_api.StopResult, error) {
+	if state.DryRun {
+		return dryRunStopResult(state.TargetLabel), nil
+	}
+
 	messages := make([]action_kit_api.Message, 0)
 
 	stopped := a.cancelStopContainer(state.ExecutionId)
+	attackEnded(state.ExecutionId, false)
 	if stopped {
 		messages = append(messages, action_kit_api.Message{
 			Level:   extutil.Ptr(action_kit_api.Info),
@@ -150,19 +207,22 @@ func (a *stopAction) Stop(_ context.Context, state *StopActionState) (*action_ki
 	}, nil
 }
 
-func (a *stopAction) stopContainer(executionId uuid.UUID, containerId string, graceful bool) error {
+func (a *stopAction) stopContainer(executionId uuid.UUID, containerId string, graceful bool, gracePeriod time.Duration) error {
 	//When the stop actions are graceful, it may take some time until the container is actually stopped.
 	//Therefore, we start the stop action, in a separate go routine, and return immediately.
 	//We save the cancel function and the error channel in a map, so that the status action can check if the stop is still completable and could also cancel if requested
 	errorChannel := make(chan error)
 	stopCtx, stopCancel := context.WithCancel(context.Background())
 
-	a.completers.Store(executionId, &completer{
+	c := &completer{
 		err:    errorChannel,
 		cancel: stopCancel,
-	})
+	}
+	a.completers.Store(executionId, c)
 	go func() {
-		errorChannel <- a.client.Stop(stopCtx, containerId, graceful)
+		killed, err := a.client.Stop(stopCtx, containerId, graceful, gracePeriod)
+		c.killed = killed
+		errorChannel <- err
 		close(errorChannel)
 	}()
 
@@ -177,18 +237,19 @@ func (a *stopAction) stopContainer(executionId uuid.UUID, containerId string, gr
 	return nil
 }
 
-func (a *stopAction) isStopContainerCompleted(executionId uuid.UUID) (bool, error) {
+// isStopContainerCompleted returns whether the stop completed and if the container had to be killed
+func (a *stopAction) isStopContainerCompleted(executionId uuid.UUID) (bool, bool, error) {
 	running, ok := a.completers.Load(executionId)
 	if !ok {
-		return true, nil
+		return true, false, nil
 	}
 
 	select {
 	case err := <-running.(*completer).err:
 		a.completers.Delete(executionId)
-		return true, err
+		return true, running.(*completer).killed, err
 	default:
-		return false, nil
+		return false, false, nil
 	}
 }
 
@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stoppingClient struct {
+	MockedClient
+	killed      bool
+	gracePeriod time.Duration
+}
+
+func (c *stoppingClient) Stop(_ context.Context, _ string, _ bool, gracePeriod time.Duration) (bool, error) {
+	c.gracePeriod = gracePeriod
+	return c.killed, nil
+}
+
+func Test_stopAction_gracePeriod(t *testing.T) {
+	tests := []struct {
+		name        string
+		graceful    bool
+		killed      bool
+		wantLevel   action_kit_api.MessageLevel
+		wantMessage string
+	}{
+		{
+			name:        "exited within grace period",
+			graceful:    true,
+			wantLevel:   action_kit_api.Info,
+			wantMessage: "Container test-container exited within the grace period of 1m0s",
+		},
+		{
+			name:        "killed after grace period",
+			graceful:    true,
+			killed:      true,
+			wantLevel:   action_kit_api.Warn,
+			wantMessage: "Container test-container exited with SIGKILL (exit code 137), it was killed after the grace period of 1m0s or by the OOM killer",
+		},
+		{
+			name:        "killed",
+			killed:      true,
+			wantLevel:   action_kit_api.Info,
+			wantMessage: "Container test-container killed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &stoppingClient{killed: tt.killed}
+			client.addContainer("test-container", nil)
+			action := NewStopContainerAction(client)
+			state := action.NewEmptyState()
+
+			_, err := action.Prepare(t.Context(), &state, action_kit_api.PrepareActionRequestBody{
+				ExecutionId: uuid.New(),
+				Config:      map[string]any{"graceful": tt.graceful, "gracePeriod": 60000},
+				Target:      &action_kit_api.Target{Attributes: map[string][]string{"container.id": {"test-container"}}},
+			})
+			require.NoError(t, err)
+
+			_, err = action.Start(t.Context(), &state)
+			require.NoError(t, err)
+			assert.Equal(t, time.Minute, client.gracePeriod)
+
+			status, err := action.(*stopAction).Status(t.Context(), &state)
+			require.NoError(t, err)
+			assert.True(t, status.Completed)
+			require.Len(t, *status.Messages, 1)
+			assert.Equal(t, tt.wantLevel, *(*status.Messages)[0].Level)
+			assert.Equal(t, tt.wantMessage, (*status.Messages)[0].Message)
+		})
+	}
+}
+
+func Test_stopAction_missingGracePeriod(t *testing.T) {
+	for name, config := range map[string]map[string]any{
+		"missing": {"graceful": true},
+		"zero":    {"graceful": true, "gracePeriod": 0},
+	} {
+		t.Run(name, func(t *testing.T) {
+			client := &stoppingClient{}
+			client.addContainer("test-container", nil)
+			action := NewStopContainerAction(client)
+			state := action.NewEmptyState()
+
+			_, err := action.Prepare(t.Context(), &state, action_kit_api.PrepareActionRequestBody{
+				ExecutionId: uuid.New(),
+				Config:      config,
+				Target:      &action_kit_api.Target{Attributes: map[string][]string{"container.id": {"test-container"}}},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, 10*time.Second, state.GracePeriod)
+
+			_, err = action.Start(t.Context(), &state)
+			require.NoError(t, err)
+			assert.Equal(t, 10*time.Second, client.gracePeriod)
+		})
+	}
+}
+
+func Test_stopAction_dryRun(t *testing.T) {
+	client := &stoppingClient{}
+	client.addContainer("test-container", nil)
+	action := NewStopContainerAction(client)
+	state := action.NewEmptyState()
+
+	_, err := action.Prepare(t.Context(), &state, action_kit_api.PrepareActionRequestBody{
+		ExecutionId: uuid.New(),
+		Config:      map[string]any{"graceful": true, "gracePeriod": 60000, "dryRun": true},
+		Target:      &action_kit_api.Target{Attributes: map[string][]string{"container.id": {"test-container"}}},
+	})
+	require.NoError(t, err)
+
+	result, err := action.Start(t.Context(), &state)
+	require.NoError(t, err)
+	assert.Zero(t, client.gracePeriod, "container must not be stopped")
+	require.Len(t, *result.Messages, 2)
+	assert.Equal(t, "stop the container gracefully, killing it after the grace period of 1m0s", (*result.Messages)[1].Message)
+
+	status, err := action.(*stopAction).Status(t.Context(), &state)
+	require.NoError(t, err)
+	assert.True(t, status.Completed)
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/rs/zerolog/log"
 	"github.com/steadybit/action-kit/go/action_kit_api/v2"
 	"github.com/steadybit/action-kit/go/action_kit_commons/ociruntime"
 	"github.com/steadybit/action-kit/go/action_kit_commons/stress"
@@ -39,6 +40,7 @@ type StressActionState struct {
 	StressOpts      stress.Opts
 	ExecutionId     uuid.UUID
 	IgnoreExitCodes []int
+	DryRun          bool
 }
 
 // Make sure stressAction implements all required interfaces
@@ -52,13 +54,21 @@ func newStressAction(
 	description func() action_kit_api.ActionDescription,
 	optsProvider stressOptsProvider,
 ) action_kit_sdk.Action[StressActionState] {
-	return &stressAction{
+	return withJournal[StressActionState](&stressAction{
 		description:  description(),
 		optsProvider: optsProvider,
 		ociRuntime:   r,
 		client:       client,
 		stresses:     syncmap.Map{},
-	}
+	})
+}
+
+func (s *StressActionState) journalRef() journalRef {
+	return journalRef{ExecutionId: s.ExecutionId, ContainerId: s.ContainerID, TargetLabel: s.TargetLabel, DryRun: s.DryRun}
+}
+
+func (s *StressActionState) describeAttack(attack *ActiveAttack) {
+	attack.SidecarId = s.Sidecar.Id
 }
 
 func (a *stressAction) NewEmptyState() StressActionState {
@@ -97,6 +107,7 @@ func (a *stressAction) Prepare(ctx context.Context, state *StressActionState, re
 
 	state.StressOpts = opts
 	state.ExecutionId = request.ExecutionId
+	state.DryRun = isDryRun(request)
 	if !extutil.ToBool(request.Config["failOnOomKill"]) {
 		state.IgnoreExitCodes = []int{137}
 	}
@@ -125,6 +136,13 @@ func (a *stressAction) Start(ctx context.Context, state *StressActionState) (*ac
 	}, nil
 }
 
+func (a *stressAction) dryRunPlan(state *StressActionState) ([]string, error) {
+	return []string{
+		fmt.Sprintf("run a stress sidecar in the cgroup of pid %d", state.Sidecar.TargetProcess.Pid),
+		fmt.Sprintf("run stress-ng %s", strings.Join(state.StressOpts.Args(), " ")),
+	}, nil
+}
+
 func (a *stressAction) Status(ctx context.Context, state *StressActionState) (*action_kit_api.StatusResult, error) {
 	exited, err := a.stressExited(state.ExecutionId)
 	if !exited {
@@ -206,6 +224,12 @@ func (a *stressAction) Stop(_ context.Context, state *StressActionState) (*actio
 			Level:   extutil.Ptr(action_kit_api.Info),
 			Message: fmt.Sprintf("Canceled stress container %s", state.TargetLabel),
 		})
+	} else if state.Sidecar.Id != "" {
+		// no handle when the extension was restarted in the meantime, the sidecar might still be running
+		ctx := context.Background() // don't use the context as the action should be stopped even if the request context is cancelled
+		if err := a.ociRuntime.Delete(ctx, state.Sidecar.Id, true); err != nil {
+			log.Debug().Err(err).Str("id", state.Sidecar.Id).Msg("no stress sidecar to delete")
+		}
 	}
 
 	return &action_kit_api.StopResult{
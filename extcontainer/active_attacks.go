@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// ActiveAttack is an attack running on this host
+type ActiveAttack struct {
+	ExecutionId  uuid.UUID      `json:"executionId"`
+	ActionId     string         `json:"actionId"`
+	ContainerId  string         `json:"containerId,omitempty"`
+	TargetLabel  string         `json:"targetLabel,omitempty"`
+	K8sNamespace string         `json:"k8sNamespace,omitempty"`
+	StartedAt    time.Time      `json:"startedAt"`
+	Deadline     *time.Time     `json:"deadline,omitempty"`
+	Parameters   map[string]any `json:"parameters,omitempty"`
+	// Journaled attacks are reverted after a restart of the extension, see attackJournal
+	Journaled bool `json:"journaled"`
+	// SidecarId is the id of the sidecar running the attack (stress, fill disk and network attacks)
+	SidecarId string `json:"sidecarId,omitempty"`
+	// NetnsRole tells how a network attack shares the network namespace with other containers (primary, shadow or
+	// passthrough), see netnsAttackTracker
+	NetnsRole string `json:"netnsRole,omitempty"`
+}
+
+// ActiveAttacks returns the attacks running on this host ordered by their start time. Attacks adopted after a restart
+// of the extension are listed with the details of the journal.
+func ActiveAttacks() []ActiveAttack {
+	attacks := map[uuid.UUID]*ActiveAttack{}
+	for executionId, attack := range attackGuard.startedAttacks() {
+		attacks[executionId] = &ActiveAttack{
+			ExecutionId:  executionId,
+			ActionId:     attack.actionId,
+			ContainerId:  attack.containerId,
+			TargetLabel:  attack.targetName,
+			K8sNamespace: attack.namespace,
+			StartedAt:    attack.startedAt,
+			Parameters:   attack.parameters,
+		}
+	}
+
+	for _, entry := range journal.list() {
+		attack, ok := attacks[entry.ExecutionId]
+		if !ok {
+			// journaled, but not counted by the guard (e.g. a start still in progress)
+			continue
+		}
+		attack.Journaled = true
+		attack.ContainerId = RemovePrefix(entry.ContainerId)
+		attack.TargetLabel = entry.TargetLabel
+		attack.StartedAt = entry.StartedAt
+		attack.Deadline = entry.Deadline
+		if entry.Parameters != nil {
+			attack.Parameters = entry.Parameters
+		}
+		if err := journal.describe(entry, attack); err != nil {
+			log.Debug().Err(err).Str("executionId", entry.ExecutionId.String()).Msg("Failed to describe journaled attack.")
+		}
+	}
+
+	result := make([]ActiveAttack, 0, len(attacks))
+	for _, attack := range attacks {
+		result = append(result, *attack)
+	}
+	sort.Slice(result, func(a, b int) bool {
+		return result[a].StartedAt.Before(result[b].StartedAt)
+	})
+	return result
+}
@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_commons/network/netfault"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func withTestGuard(t *testing.T) {
+	old := attackGuard
+	attackGuard = &blastRadiusGuard{attacks: map[uuid.UUID]guardedAttack{}, now: time.Now}
+	t.Cleanup(func() { attackGuard = old })
+}
+
+func Test_ActiveAttacks(t *testing.T) {
+	withTestJournal(t)
+	withTestGuard(t)
+
+	restart := action_kit_api.PrepareActionRequestBody{
+		ExecutionId: uuid.New(),
+		Config:      map[string]any{"graceful": true},
+		Target: &action_kit_api.Target{
+			Name:       "web",
+			Attributes: map[string][]string{"container.id": {"containerd://c2"}, "k8s.namespace": {"shop"}},
+		},
+	}
+	require.NoError(t, attackGuard.admit(t.Context(), "restart", restart))
+	attackGuard.started("restart", restart.ExecutionId)
+
+	_, journaled := startJournaled(t, 30000)
+
+	// prepared only, not active yet
+	require.NoError(t, attackGuard.admit(t.Context(), "restart", guardRequest("c3", "")))
+
+	attacks := ActiveAttacks()
+	require.Len(t, attacks, 2)
+
+	assert.Equal(t, restart.ExecutionId, attacks[0].ExecutionId)
+	assert.Equal(t, "restart", attacks[0].ActionId)
+	assert.Equal(t, "c2", attacks[0].ContainerId)
+	assert.Equal(t, "web", attacks[0].TargetLabel)
+	assert.Equal(t, "shop", attacks[0].K8sNamespace)
+	assert.Equal(t, map[string]any{"graceful": true}, attacks[0].Parameters)
+	assert.False(t, attacks[0].Journaled)
+	assert.Nil(t, attacks[0].Deadline)
+
+	assert.Equal(t, journaled.ExecutionId, attacks[1].ExecutionId)
+	assert.Equal(t, "journal-test", attacks[1].ActionId)
+	assert.Equal(t, "container-1", attacks[1].ContainerId)
+	assert.Equal(t, map[string]any{"duration": 30000}, attacks[1].Parameters)
+	assert.True(t, attacks[1].Journaled)
+	require.NotNil(t, attacks[1].Deadline)
+	assert.WithinDuration(t, attacks[1].StartedAt.Add(30*time.Second), *attacks[1].Deadline, time.Millisecond)
+
+	attackEnded(restart.ExecutionId, false)
+	attacks = ActiveAttacks()
+	require.Len(t, attacks, 1)
+	assert.Equal(t, journaled.ExecutionId, attacks[0].ExecutionId)
+}
+
+func Test_NetworkActionState_describeAttack(t *testing.T) {
+	tests := []struct {
+		name  string
+		state NetworkActionState
+		want  string
+	}{
+		{name: "primary", state: NetworkActionState{NetnsClaimed: true}, want: "primary"},
+		{name: "shadow", state: NetworkActionState{NetnsClaimed: true, IsShadow: true}, want: "shadow"},
+		{name: "passthrough", state: NetworkActionState{}, want: "passthrough"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.state.Sidecar = netfault.SidecarOpts{Id: "sidecar-1"}
+			var attack ActiveAttack
+			tt.state.describeAttack(&attack)
+			assert.Equal(t, "sidecar-1", attack.SidecarId)
+			assert.Equal(t, tt.want, attack.NetnsRole)
+		})
+	}
+}
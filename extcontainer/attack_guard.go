@@ -0,0 +1,232 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-container/config"
+	extension_kit "github.com/steadybit/extension-kit"
+)
+
+const (
+	// guardPrepareTimeout is how long a prepared attack counts against the limits without being started
+	guardPrepareTimeout = 2 * time.Minute
+	// guardDiscoveryTimeout is how long the guard waits for the discovered containers, e.g. while the first discovery
+	// after a start of the extension is still running
+	guardDiscoveryTimeout = 10 * time.Second
+)
+
+// blastRadiusGuard limits the attacks running on this host at once. Attacks are counted from a successful prepare
+// until they end, so prepares running concurrently can't exceed the limits together.
+type blastRadiusGuard struct {
+	mu      sync.Mutex
+	attacks map[uuid.UUID]guardedAttack
+	targets targetLister
+	now     func() time.Time
+}
+
+type guardedAttack struct {
+	actionId    string
+	containerId string
+	targetName  string
+	namespace   string
+	parameters  map[string]any
+	preparedAt  time.Time
+	startedAt   time.Time
+	started     bool
+}
+
+// GuardStatus are the limits of the blast radius guard and the current counts, 0 means unlimited
+type GuardStatus struct {
+	MaxConcurrentAttacks         int            `json:"maxConcurrentAttacks"`
+	MaxAttackedContainersPercent int            `json:"maxAttackedContainersPercent"`
+	MaxAttacksPerK8sNamespace    int            `json:"maxAttacksPerK8sNamespace"`
+	Attacks                      int            `json:"attacks"`
+	AttackedContainers           int            `json:"attackedContainers"`
+	DiscoveredContainers         int            `json:"discoveredContainers"`
+	AttacksPerK8sNamespace       map[string]int `json:"attacksPerK8sNamespace"`
+}
+
+var attackGuard = &blastRadiusGuard{attacks: map[uuid.UUID]guardedAttack{}, now: time.Now}
+
+// InitAttackGuard sets the discovery used to limit the percentage of attacked containers
+func InitAttackGuard(targets targetLister) {
+	attackGuard.mu.Lock()
+	defer attackGuard.mu.Unlock()
+	attackGuard.targets = targets
+}
+
+// AttackGuardStatus returns the limits and current counts of the blast radius guard
+func AttackGuardStatus() GuardStatus {
+	discovered, err := attackGuard.discoveredContainers(context.Background())
+	if err != nil {
+		log.Debug().Err(err).Msg("Failed to count discovered containers for the blast radius guard.")
+	}
+
+	attackGuard.mu.Lock()
+	defer attackGuard.mu.Unlock()
+	attackGuard.expireLocked()
+	cfg := config.Current()
+	return GuardStatus{
+		MaxConcurrentAttacks:         cfg.MaxConcurrentAttacks,
+		MaxAttackedContainersPercent: cfg.MaxAttackedContainersPercent,
+		MaxAttacksPerK8sNamespace:    cfg.MaxAttacksPerK8sNamespace,
+		Attacks:                      len(attackGuard.attacks),
+		AttackedContainers:           len(attackGuard.attackedContainersLocked("")),
+		DiscoveredContainers:         discovered,
+		AttacksPerK8sNamespace:       attackGuard.attacksPerNamespaceLocked(),
+	}
+}
+
+// admit counts the prepared attack against the limits, it returns an error if the attack would exceed any of them
+func (g *blastRadiusGuard) admit(ctx context.Context, actionId string, request action_kit_api.PrepareActionRequestBody) error {
+	attack := guardedAttack{actionId: actionId, parameters: request.Config, preparedAt: g.now()}
+	if request.Target != nil {
+		attack.targetName = request.Target.Name
+		if ids := request.Target.Attributes["container.id"]; len(ids) > 0 {
+			attack.containerId = RemovePrefix(ids[0])
+		}
+		if namespaces := request.Target.Attributes["k8s.namespace"]; len(namespaces) > 0 {
+			attack.namespace = namespaces[0]
+		}
+	}
+
+	cfg := config.Current()
+	var discovered int
+	if cfg.MaxAttackedContainersPercent > 0 {
+		// without the discovered containers the percentage can't be checked, the attack is rejected rather than
+		// admitted unchecked
+		var err error
+		if discovered, err = g.discoveredContainers(ctx); err != nil {
+			return extension_kit.ToError("Attack rejected, the attacked containers can't be limited to a percentage of the containers on this host.", err)
+		}
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.expireLocked()
+	if _, ok := g.attacks[request.ExecutionId]; ok {
+		return nil
+	}
+
+	if limit := cfg.MaxConcurrentAttacks; limit > 0 && len(g.attacks) >= limit {
+		return extension_kit.ToError(fmt.Sprintf("Attack rejected, %d attacks are running on this host already and at most %d are allowed.", len(g.attacks), limit), nil)
+	}
+
+	if limit := cfg.MaxAttacksPerK8sNamespace; limit > 0 && attack.namespace != "" {
+		if count := g.attacksPerNamespaceLocked()[attack.namespace]; count >= limit {
+			return extension_kit.ToError(fmt.Sprintf("Attack rejected, %d attacks are running in the namespace %s on this host already and at most %d are allowed.", count, attack.namespace, limit), nil)
+		}
+	}
+
+	if limit := cfg.MaxAttackedContainersPercent; limit > 0 {
+		attacked := len(g.attackedContainersLocked(attack.containerId))
+		if attacked*100 > limit*discovered {
+			return extension_kit.ToError(fmt.Sprintf("Attack rejected, %d of %d containers on this host would be under attack and at most %d%% are allowed.", attacked, discovered, limit), nil)
+		}
+	}
+
+	g.attacks[request.ExecutionId] = attack
+	return nil
+}
+
+// started marks the attack as started, attacks recovered after a restart of the extension are added
+func (g *blastRadiusGuard) started(actionId string, executionId uuid.UUID) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	attack, ok := g.attacks[executionId]
+	if !ok {
+		attack = guardedAttack{actionId: actionId, preparedAt: g.now()}
+	}
+	if !attack.started {
+		attack.started = true
+		attack.startedAt = g.now()
+	}
+	g.attacks[executionId] = attack
+}
+
+// startedAttacks returns the attacks started and not yet ended
+func (g *blastRadiusGuard) startedAttacks() map[uuid.UUID]guardedAttack {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	result := map[uuid.UUID]guardedAttack{}
+	for executionId, attack := range g.attacks {
+		if attack.started {
+			result[executionId] = attack
+		}
+	}
+	return result
+}
+
+func (g *blastRadiusGuard) release(executionId uuid.UUID) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.attacks, executionId)
+}
+
+// expireLocked forgets prepared attacks which were never started
+func (g *blastRadiusGuard) expireLocked() {
+	maps.DeleteFunc(g.attacks, func(executionId uuid.UUID, attack guardedAttack) bool {
+		if !attack.started && g.now().Sub(attack.preparedAt) > guardPrepareTimeout {
+			log.Debug().Str("executionId", executionId.String()).Str("actionId", attack.actionId).Msg("Prepared attack was not started, no longer counting it.")
+			return true
+		}
+		return false
+	})
+}
+
+// attackedContainersLocked returns the containers under attack including the additional one, attacks with an unknown
+// container count as a container of their own
+func (g *blastRadiusGuard) attackedContainersLocked(additional string) map[string]bool {
+	result := map[string]bool{}
+	if additional != "" {
+		result[additional] = true
+	}
+	for executionId, attack := range g.attacks {
+		if attack.containerId != "" {
+			result[attack.containerId] = true
+		} else {
+			result[executionId.String()] = true
+		}
+	}
+	return result
+}
+
+func (g *blastRadiusGuard) attacksPerNamespaceLocked() map[string]int {
+	result := map[string]int{}
+	for _, attack := range g.attacks {
+		if attack.namespace != "" {
+			result[attack.namespace]++
+		}
+	}
+	return result
+}
+
+func (g *blastRadiusGuard) discoveredContainers(ctx context.Context) (int, error) {
+	g.mu.Lock()
+	targets := g.targets
+	g.mu.Unlock()
+	if targets == nil {
+		return 0, fmt.Errorf("container discovery not initialized")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, guardDiscoveryTimeout)
+	defer cancel()
+	discovered, err := targets.DiscoverTargets(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count discovered containers: %w", err)
+	}
+	if len(discovered) == 0 {
+		return 0, fmt.Errorf("no containers discovered")
+	}
+	return len(discovered), nil
+}
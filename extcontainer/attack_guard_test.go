@@ -0,0 +1,156 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/steadybit/extension-container/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func guardRequest(containerId, namespace string) action_kit_api.PrepareActionRequestBody {
+	attributes := map[string][]string{"container.id": {"containerd://" + containerId}}
+	if namespace != "" {
+		attributes["k8s.namespace"] = []string{namespace}
+	}
+	return action_kit_api.PrepareActionRequestBody{
+		ExecutionId: uuid.New(),
+		Target:      &action_kit_api.Target{Attributes: attributes},
+	}
+}
+
+func withGuardLimits(t *testing.T, concurrent, percent, perNamespace int) {
+	config.Config.MaxConcurrentAttacks = concurrent
+	config.Config.MaxAttackedContainersPercent = percent
+	config.Config.MaxAttacksPerK8sNamespace = perNamespace
+	t.Cleanup(func() {
+		config.Config.MaxConcurrentAttacks = 0
+		config.Config.MaxAttackedContainersPercent = 0
+		config.Config.MaxAttacksPerK8sNamespace = 0
+	})
+}
+
+func Test_blastRadiusGuard_maxConcurrentAttacks(t *testing.T) {
+	withGuardLimits(t, 2, 0, 0)
+	g := &blastRadiusGuard{attacks: map[uuid.UUID]guardedAttack{}, now: time.Now}
+
+	first := guardRequest("a", "")
+	require.NoError(t, g.admit(t.Context(), "stress-cpu", first))
+	require.NoError(t, g.admit(t.Context(), "stress-cpu", guardRequest("b", "")))
+	assert.ErrorContains(t, g.admit(t.Context(), "stress-cpu", guardRequest("c", "")), "2 attacks are running on this host already and at most 2 are allowed")
+
+	// preparing the same execution again is no additional attack
+	assert.NoError(t, g.admit(t.Context(), "stress-cpu", first))
+
+	g.release(first.ExecutionId)
+	assert.NoError(t, g.admit(t.Context(), "stress-cpu", guardRequest("c", "")))
+}
+
+func Test_blastRadiusGuard_maxAttacksPerK8sNamespace(t *testing.T) {
+	withGuardLimits(t, 0, 0, 1)
+	g := &blastRadiusGuard{attacks: map[uuid.UUID]guardedAttack{}, now: time.Now}
+
+	require.NoError(t, g.admit(t.Context(), "pause", guardRequest("a", "shop")))
+	assert.ErrorContains(t, g.admit(t.Context(), "pause", guardRequest("b", "shop")), "in the namespace shop")
+	assert.NoError(t, g.admit(t.Context(), "pause", guardRequest("c", "payment")))
+	assert.NoError(t, g.admit(t.Context(), "pause", guardRequest("d", "")))
+}
+
+func Test_blastRadiusGuard_maxAttackedContainersPercent(t *testing.T) {
+	withGuardLimits(t, 0, 50, 0)
+	g := &blastRadiusGuard{
+		attacks: map[uuid.UUID]guardedAttack{},
+		targets: staticTargets{{Label: "a"}, {Label: "b"}, {Label: "c"}, {Label: "d"}},
+		now:     time.Now,
+	}
+
+	require.NoError(t, g.admit(t.Context(), "stress-cpu", guardRequest("a", "")))
+	require.NoError(t, g.admit(t.Context(), "stress-cpu", guardRequest("b", "")))
+	// another attack on an attacked container doesn't widen the blast radius
+	require.NoError(t, g.admit(t.Context(), "stress-memory", guardRequest("a", "")))
+	assert.ErrorContains(t, g.admit(t.Context(), "stress-cpu", guardRequest("c", "")), "3 of 4 containers on this host would be under attack and at most 50% are allowed")
+}
+
+type failingTargets struct {
+	err error
+}
+
+func (f failingTargets) DiscoverTargets(_ context.Context) ([]discovery_kit_api.Target, error) {
+	return nil, f.err
+}
+
+func Test_blastRadiusGuard_maxAttackedContainersPercentWithoutDiscovery(t *testing.T) {
+	tests := []struct {
+		name    string
+		targets targetLister
+	}{
+		{name: "not initialized"},
+		{name: "discovery failed", targets: failingTargets{err: errors.New("connection refused")}},
+		{name: "discovery not ready", targets: failingTargets{err: context.DeadlineExceeded}},
+		{name: "nothing discovered", targets: staticTargets{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withGuardLimits(t, 0, 50, 0)
+			g := &blastRadiusGuard{attacks: map[uuid.UUID]guardedAttack{}, targets: tt.targets, now: time.Now}
+
+			err := g.admit(t.Context(), "stress-cpu", guardRequest("a", ""))
+			assert.ErrorContains(t, err, "can't be limited to a percentage")
+			assert.Empty(t, g.attacks)
+		})
+	}
+
+	// without the percentage limit the discovery isn't needed
+	withGuardLimits(t, 0, 0, 0)
+	g := &blastRadiusGuard{attacks: map[uuid.UUID]guardedAttack{}, targets: failingTargets{err: errors.New("connection refused")}, now: time.Now}
+	assert.NoError(t, g.admit(t.Context(), "stress-cpu", guardRequest("a", "")))
+}
+
+func Test_blastRadiusGuard_expiresUnstartedAttacks(t *testing.T) {
+	withGuardLimits(t, 1, 0, 0)
+	now := time.Now()
+	g := &blastRadiusGuard{attacks: map[uuid.UUID]guardedAttack{}, now: func() time.Time { return now }}
+
+	started := guardRequest("a", "")
+	require.NoError(t, g.admit(t.Context(), "pause", started))
+	g.started("pause", started.ExecutionId)
+	now = now.Add(guardPrepareTimeout + time.Second)
+	assert.Error(t, g.admit(t.Context(), "pause", guardRequest("b", "")))
+
+	g.release(started.ExecutionId)
+	require.NoError(t, g.admit(t.Context(), "pause", guardRequest("b", "")))
+	now = now.Add(guardPrepareTimeout + time.Second)
+	assert.NoError(t, g.admit(t.Context(), "pause", guardRequest("c", "")))
+}
+
+func Test_AttackGuardStatus(t *testing.T) {
+	withGuardLimits(t, 10, 0, 5)
+	attacks, targets := attackGuard.attacks, attackGuard.targets
+	t.Cleanup(func() {
+		attackGuard.attacks = attacks
+		attackGuard.targets = targets
+	})
+	attackGuard.attacks = map[uuid.UUID]guardedAttack{}
+	InitAttackGuard(staticTargets{{Label: "a"}, {Label: "b"}})
+
+	require.NoError(t, attackGuard.admit(t.Context(), "pause", guardRequest("a", "shop")))
+	require.NoError(t, attackGuard.admit(t.Context(), "stress-cpu", guardRequest("a", "shop")))
+
+	assert.Equal(t, GuardStatus{
+		MaxConcurrentAttacks:      10,
+		MaxAttacksPerK8sNamespace: 5,
+		Attacks:                   2,
+		AttackedContainers:        1,
+		DiscoveredContainers:      2,
+		AttacksPerK8sNamespace:    map[string]int{"shop": 2},
+	}, AttackGuardStatus())
+}
@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/steadybit/extension-container/extcontainer/metrics"
+)
+
+// attackMetrics counts the attacks per action. An attack may end with a completed status and a stop call afterward,
+// so the running attacks are tracked to count each of them once.
+var attackMetrics = struct {
+	sync.Mutex
+	running map[uuid.UUID]string
+}{running: map[uuid.UUID]string{}}
+
+func attackStarted(actionId string, executionId uuid.UUID, err error) {
+	if err != nil {
+		attackGuard.release(executionId)
+		metrics.AttacksFailed.WithLabelValues(actionId).Inc()
+		return
+	}
+	attackGuard.started(actionId, executionId)
+
+	attackMetrics.Lock()
+	defer attackMetrics.Unlock()
+	if _, ok := attackMetrics.running[executionId]; ok {
+		return
+	}
+	attackMetrics.running[executionId] = actionId
+	metrics.AttacksActive.WithLabelValues(actionId).Inc()
+}
+
+func attackEnded(executionId uuid.UUID, failed bool) {
+	attackGuard.release(executionId)
+
+	attackMetrics.Lock()
+	defer attackMetrics.Unlock()
+	actionId, ok := attackMetrics.running[executionId]
+	if !ok {
+		return
+	}
+	delete(attackMetrics.running, executionId)
+
+	metrics.AttacksActive.WithLabelValues(actionId).Dec()
+	if failed {
+		metrics.AttacksFailed.WithLabelValues(actionId).Inc()
+	} else {
+		metrics.AttacksCompleted.WithLabelValues(actionId).Inc()
+	}
+}
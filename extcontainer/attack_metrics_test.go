@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/steadybit/extension-container/extcontainer/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_attackMetrics(t *testing.T) {
+	const actionId = "attack-metrics-test"
+	active := metrics.AttacksActive.WithLabelValues(actionId)
+	completed := metrics.AttacksCompleted.WithLabelValues(actionId)
+	failed := metrics.AttacksFailed.WithLabelValues(actionId)
+
+	first, second := uuid.New(), uuid.New()
+	attackStarted(actionId, first, nil)
+	attackStarted(actionId, second, nil)
+	attackStarted(actionId, uuid.New(), errors.New("boom"))
+	assert.Equal(t, 2.0, testutil.ToFloat64(active))
+	assert.Equal(t, 1.0, testutil.ToFloat64(failed))
+
+	// completed status followed by stop is counted once
+	attackEnded(first, false)
+	attackEnded(first, false)
+	attackEnded(second, true)
+
+	assert.Equal(t, 0.0, testutil.ToFloat64(active))
+	assert.Equal(t, 1.0, testutil.ToFloat64(completed))
+	assert.Equal(t, 2.0, testutil.ToFloat64(failed))
+}
+
+func Test_netnsClaimsHeld(t *testing.T) {
+	const id = "test-netns-claims-held"
+	t.Cleanup(func() { fullyRelease(id) })
+	// other claims might be held, only the changes are compared
+	baseline := map[ClaimResult]float64{}
+	for _, claim := range []ClaimResult{ClaimPrimary, ClaimShadow, ClaimPassthrough} {
+		baseline[claim] = testutil.ToFloat64(metrics.NetnsClaimsHeld.WithLabelValues(claim.String()))
+	}
+	held := func(claim ClaimResult) float64 {
+		return testutil.ToFloat64(metrics.NetnsClaimsHeld.WithLabelValues(claim.String())) - baseline[claim]
+	}
+	optsA := []byte(`{"Delay":100}`)
+	optsB := []byte(`{"Delay":200}`)
+
+	assert.Equal(t, ClaimPrimary, claimNetnsForAttack(id, optsA))
+	assert.Equal(t, ClaimShadow, claimNetnsForAttack(id, optsA))
+	assert.Equal(t, ClaimPassthrough, claimNetnsForAttack(id, optsB))
+	assert.Equal(t, 1.0, held(ClaimPrimary))
+	assert.Equal(t, 1.0, held(ClaimShadow))
+	assert.Equal(t, 1.0, held(ClaimPassthrough))
+
+	releaseNetnsPassthrough()
+	releaseNetnsForAttack(id)
+	assert.Equal(t, 1.0, held(ClaimPrimary))
+	assert.Equal(t, 0.0, held(ClaimShadow))
+	assert.Equal(t, 0.0, held(ClaimPassthrough))
+
+	releaseNetnsForAttack(id)
+	assert.Equal(t, 0.0, held(ClaimPrimary))
+	assert.Equal(t, 0.0, held(ClaimShadow))
+}
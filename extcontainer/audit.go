@@ -0,0 +1,280 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-container/extcontainer/audit"
+	"golang.org/x/sync/syncmap"
+)
+
+// auditedAction records the lifecycle transitions of an action in the audit log. Status calls are only recorded when
+// they end the attack, as they are polled every few seconds.
+type auditedAction[T any, PT interface {
+	*T
+	journaledState
+}] struct {
+	action     action_kit_sdk.Action[T]
+	id         string
+	withStatus bool
+	withStop   bool
+	// experiments holds the experiment of each execution, as only prepare is told about it
+	experiments syncmap.Map //map[uuid.UUID]auditedExperiment
+}
+
+type auditedExperiment struct {
+	key         string
+	executionId int
+	preparedAt  time.Time
+	// started is set once the attack was started, until then the experiment expires like a prepared attack in the guard
+	started bool
+}
+
+// the action_kit_sdk decides by the implemented interfaces whether an action has status and stop, so there is a
+// wrapper for each combination
+type auditedActionWithStatus[T any, PT interface {
+	*T
+	journaledState
+}] struct {
+	*auditedAction[T, PT]
+}
+
+type auditedActionWithStop[T any, PT interface {
+	*T
+	journaledState
+}] struct {
+	*auditedAction[T, PT]
+}
+
+type auditedActionWithStatusAndStop[T any, PT interface {
+	*T
+	journaledState
+}] struct {
+	*auditedAction[T, PT]
+}
+
+var _ action_kit_sdk.ActionWithStatus[PauseActionState] = (*auditedActionWithStatus[PauseActionState, *PauseActionState])(nil)
+var _ action_kit_sdk.ActionWithStop[PauseActionState] = (*auditedActionWithStop[PauseActionState, *PauseActionState])(nil)
+var _ action_kit_sdk.ActionWithStatus[PauseActionState] = (*auditedActionWithStatusAndStop[PauseActionState, *PauseActionState])(nil)
+var _ action_kit_sdk.ActionWithStop[PauseActionState] = (*auditedActionWithStatusAndStop[PauseActionState, *PauseActionState])(nil)
+
+// WithAudit records the prepare, start, status and stop calls of the action in the audit log.
+func WithAudit[T any, PT interface {
+	*T
+	journaledState
+}](action action_kit_sdk.Action[T]) action_kit_sdk.Action[T] {
+	a := &auditedAction[T, PT]{action: action, id: action.Describe().Id}
+	_, a.withStatus = action.(action_kit_sdk.ActionWithStatus[T])
+	_, a.withStop = action.(action_kit_sdk.ActionWithStop[T])
+
+	switch {
+	case a.withStatus && a.withStop:
+		return &auditedActionWithStatusAndStop[T, PT]{a}
+	case a.withStatus:
+		return &auditedActionWithStatus[T, PT]{a}
+	case a.withStop:
+		return &auditedActionWithStop[T, PT]{a}
+	default:
+		return a
+	}
+}
+
+func (a *auditedAction[T, PT]) NewEmptyState() T {
+	return a.action.NewEmptyState()
+}
+
+func (a *auditedAction[T, PT]) Describe() action_kit_api.ActionDescription {
+	return a.action.Describe()
+}
+
+func (a *auditedAction[T, PT]) Prepare(ctx context.Context, state *T, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	if request.ExecutionContext != nil {
+		a.expireExperiments()
+		experiment := auditedExperiment{preparedAt: time.Now()}
+		if request.ExecutionContext.ExperimentKey != nil {
+			experiment.key = *request.ExecutionContext.ExperimentKey
+		}
+		if request.ExecutionContext.ExecutionId != nil {
+			experiment.executionId = *request.ExecutionContext.ExecutionId
+		}
+		a.experiments.Store(request.ExecutionId, experiment)
+	}
+
+	ctx, commands := audit.WithCommands(ctx)
+	result, err := a.action.Prepare(ctx, state, request)
+
+	entry := a.entry(audit.PhasePrepare, state)
+	entry.Commands = commands()
+	// the state is incomplete when prepare failed
+	entry.ExecutionId = request.ExecutionId
+	entry.Parameters = request.Config
+	if raw, err := json.Marshal(state); err == nil {
+		entry.State = raw
+	}
+	if result != nil {
+		a.record(entry, result.Messages, result.Error, err)
+	} else {
+		a.record(entry, nil, nil, err)
+	}
+
+	if err != nil || (result != nil && result.Error != nil) {
+		a.experiments.Delete(request.ExecutionId)
+	}
+	return result, err
+}
+
+func (a *auditedAction[T, PT]) Start(ctx context.Context, state *T) (*action_kit_api.StartResult, error) {
+	ctx, commands := audit.WithCommands(ctx)
+	result, err := a.action.Start(ctx, state)
+
+	entry := a.entry(audit.PhaseStart, state)
+	entry.Commands = commands()
+	if result != nil {
+		a.record(entry, result.Messages, result.Error, err)
+	} else {
+		a.record(entry, nil, nil, err)
+	}
+
+	if !a.withStatus && !a.withStop {
+		a.experiments.Delete(entry.ExecutionId)
+	} else if value, ok := a.experiments.Load(entry.ExecutionId); ok && err == nil && (result == nil || result.Error == nil) {
+		experiment := value.(auditedExperiment)
+		experiment.started = true
+		a.experiments.Store(entry.ExecutionId, experiment)
+	}
+	return result, err
+}
+
+// expireExperiments drops the experiments of attacks which were prepared but not started within guardPrepareTimeout
+func (a *auditedAction[T, PT]) expireExperiments() {
+	now := time.Now()
+	a.experiments.Range(func(executionId, value any) bool {
+		if experiment := value.(auditedExperiment); !experiment.started && now.Sub(experiment.preparedAt) > guardPrepareTimeout {
+			a.experiments.Delete(executionId)
+		}
+		return true
+	})
+}
+
+func (a *auditedAction[T, PT]) status(ctx context.Context, state *T) (*action_kit_api.StatusResult, error) {
+	ctx, commands := audit.WithCommands(ctx)
+	result, err := a.action.(action_kit_sdk.ActionWithStatus[T]).Status(ctx, state)
+	if err == nil && (result == nil || (!result.Completed && result.Error == nil)) {
+		return result, err
+	}
+
+	entry := a.entry(audit.PhaseStatus, state)
+	entry.Commands = commands()
+	if result != nil {
+		a.record(entry, result.Messages, result.Error, err)
+	} else {
+		a.record(entry, nil, nil, err)
+	}
+
+	if !a.withStop {
+		a.experiments.Delete(entry.ExecutionId)
+	}
+	return result, err
+}
+
+func (a *auditedAction[T, PT]) stop(ctx context.Context, state *T) (*action_kit_api.StopResult, error) {
+	ctx, commands := audit.WithCommands(ctx)
+	result, err := a.action.(action_kit_sdk.ActionWithStop[T]).Stop(ctx, state)
+
+	entry := a.entry(audit.PhaseStop, state)
+	entry.Commands = commands()
+	if result != nil {
+		a.record(entry, result.Messages, result.Error, err)
+	} else {
+		a.record(entry, nil, nil, err)
+	}
+
+	a.experiments.Delete(entry.ExecutionId)
+	return result, err
+}
+
+func (a *auditedAction[T, PT]) entry(phase string, state *T) audit.Entry {
+	ref := PT(state).journalRef()
+	entry := audit.Entry{
+		Phase:       phase,
+		ExecutionId: ref.ExecutionId,
+		ActionId:    a.id,
+		ContainerId: ref.ContainerId,
+		TargetLabel: ref.TargetLabel,
+		DryRun:      ref.DryRun,
+	}
+	if value, ok := a.experiments.Load(ref.ExecutionId); ok {
+		experiment := value.(auditedExperiment)
+		entry.ExperimentKey = experiment.key
+		entry.ExperimentExecutionId = experiment.executionId
+	}
+	return entry
+}
+
+func (a *auditedAction[T, PT]) record(entry audit.Entry, messages *action_kit_api.Messages, kitErr *action_kit_api.ActionKitError, err error) {
+	entry.Outcome = audit.OutcomeSucceeded
+	if entry.Phase == audit.PhaseStatus {
+		entry.Outcome = audit.OutcomeCompleted
+	}
+	if err != nil {
+		entry.Outcome = audit.OutcomeFailed
+		entry.Error = err.Error()
+	} else if kitErr != nil {
+		entry.Outcome = audit.OutcomeFailed
+		entry.Error = kitErr.Title
+	}
+	entry.Messages = messageTexts(messages)
+	audit.Record(entry)
+}
+
+func (a *auditedActionWithStatus[T, PT]) Status(ctx context.Context, state *T) (*action_kit_api.StatusResult, error) {
+	return a.status(ctx, state)
+}
+
+func (a *auditedActionWithStop[T, PT]) Stop(ctx context.Context, state *T) (*action_kit_api.StopResult, error) {
+	return a.stop(ctx, state)
+}
+
+func (a *auditedActionWithStatusAndStop[T, PT]) Status(ctx context.Context, state *T) (*action_kit_api.StatusResult, error) {
+	return a.status(ctx, state)
+}
+
+func (a *auditedActionWithStatusAndStop[T, PT]) Stop(ctx context.Context, state *T) (*action_kit_api.StopResult, error) {
+	return a.stop(ctx, state)
+}
+
+// recordRevert records an attack reverted by the extension itself, after a restart or by an emergency stop
+func recordRevert(report RevertReport) {
+	entry := audit.Entry{
+		Phase:       audit.PhaseRevert,
+		Outcome:     audit.OutcomeSucceeded,
+		ExecutionId: report.ExecutionId,
+		ActionId:    report.ActionId,
+		ContainerId: report.ContainerId,
+		TargetLabel: report.TargetLabel,
+		Messages:    report.Messages,
+		Commands:    report.Commands,
+	}
+	if !report.Reverted {
+		entry.Outcome = audit.OutcomeFailed
+		entry.Error = report.Error
+	}
+	audit.Record(entry)
+}
+
+func messageTexts(messages *action_kit_api.Messages) []string {
+	if messages == nil {
+		return nil
+	}
+	texts := make([]string, 0, len(*messages))
+	for _, message := range *messages {
+		texts = append(texts, message.Message)
+	}
+	return texts
+}
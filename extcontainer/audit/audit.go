@@ -0,0 +1,217 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+// Package audit records every lifecycle transition of the attacks in an append-only log, to prove who attacked what and
+// when. The entries are written as JSON lines to a rotated file and/or stdout and the recent ones are kept in memory
+// for the /audit endpoint.
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/extension-kit/exthttp"
+)
+
+const (
+	PhasePrepare = "prepare"
+	PhaseStart   = "start"
+	PhaseStatus  = "status"
+	PhaseStop    = "stop"
+	// PhaseRevert is an attack reverted by the extension itself, after a restart or by an emergency stop
+	PhaseRevert = "revert"
+)
+
+const (
+	OutcomeSucceeded = "succeeded"
+	OutcomeCompleted = "completed"
+	OutcomeFailed    = "failed"
+)
+
+const (
+	// recentEntries is the number of entries kept in memory for the /audit endpoint
+	recentEntries = 1000
+	defaultLimit  = 100
+)
+
+type Entry struct {
+	Seq                   uint64    `json:"seq"`
+	Time                  time.Time `json:"time"`
+	Phase                 string    `json:"phase"`
+	Outcome               string    `json:"outcome"`
+	Error                 string    `json:"error,omitempty"`
+	ExecutionId           uuid.UUID `json:"executionId"`
+	ExperimentKey         string    `json:"experimentKey,omitempty"`
+	ExperimentExecutionId int       `json:"experimentExecutionId,omitempty"`
+	ActionId              string    `json:"actionId"`
+	ContainerId           string    `json:"containerId,omitempty"`
+	TargetLabel           string    `json:"targetLabel,omitempty"`
+	DryRun                bool      `json:"dryRun,omitempty"`
+	// Parameters are the parameters of the action as sent by the platform, only set on prepare
+	Parameters map[string]any `json:"parameters,omitempty"`
+	// State is the prepared state holding the resolved parameters (e.g. the network filters or stress-ng options),
+	// only set on prepare
+	State json.RawMessage `json:"state,omitempty"`
+	// Messages are the messages reported by the action
+	Messages []string `json:"messages,omitempty"`
+	// Commands are the commands executed by the action, e.g. tc, ip, iptables or stress-ng in a sidecar or the calls of
+	// the container runtime api
+	Commands []string `json:"commands,omitempty"`
+}
+
+type Options struct {
+	// File is appended to, it is rotated once it exceeds MaxSize bytes keeping MaxBackups rotated files
+	File       string
+	MaxSize    int64
+	MaxBackups int
+	Stdout     bool
+}
+
+type Page struct {
+	Entries []Entry `json:"entries"`
+	// Total is the number of matching entries kept in memory
+	Total  int `json:"total"`
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+}
+
+type auditLog struct {
+	mu      sync.Mutex
+	seq     uint64
+	writers []io.Writer
+	closers []io.Closer
+	recent  []Entry
+}
+
+var defaultLog = &auditLog{}
+
+// Init sets where the entries are written to, without file and stdout the entries are kept in memory only.
+func Init(opts Options) error {
+	var writers []io.Writer
+	var closers []io.Closer
+	if opts.File != "" {
+		file, err := openRotatingFile(opts.File, opts.MaxSize, opts.MaxBackups)
+		if err != nil {
+			return err
+		}
+		writers = append(writers, file)
+		closers = append(closers, file)
+	}
+	if opts.Stdout {
+		writers = append(writers, os.Stdout)
+	}
+
+	defaultLog.mu.Lock()
+	defer defaultLog.mu.Unlock()
+	for _, closer := range defaultLog.closers {
+		_ = closer.Close()
+	}
+	defaultLog.writers = writers
+	defaultLog.closers = closers
+	return nil
+}
+
+// Record appends the entry to the audit log
+func Record(entry Entry) {
+	defaultLog.record(entry)
+}
+
+// Recent returns the recent entries newest first, only those of the execution if it isn't uuid.Nil
+func Recent(executionId uuid.UUID, offset, limit int) Page {
+	return defaultLog.page(executionId, offset, limit)
+}
+
+// Handler pages through the recent entries using the query parameters offset, limit and executionId
+func Handler(w http.ResponseWriter, r *http.Request, _ []byte) {
+	query := r.URL.Query()
+	offset, err := intParam(query.Get("offset"), 0)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid offset: %s", err), http.StatusBadRequest)
+		return
+	}
+	limit, err := intParam(query.Get("limit"), defaultLimit)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid limit: %s", err), http.StatusBadRequest)
+		return
+	}
+	var executionId uuid.UUID
+	if raw := query.Get("executionId"); raw != "" {
+		if executionId, err = uuid.Parse(raw); err != nil {
+			http.Error(w, fmt.Sprintf("invalid executionId: %s", err), http.StatusBadRequest)
+			return
+		}
+	}
+	exthttp.WriteBody(w, Recent(executionId, offset, limit))
+}
+
+func intParam(raw string, defaultValue int) (int, error) {
+	if raw == "" {
+		return defaultValue, nil
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, err
+	}
+	if value < 0 {
+		return 0, fmt.Errorf("must not be negative")
+	}
+	return value, nil
+}
+
+func (l *auditLog) record(entry Entry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.seq++
+	entry.Seq = l.seq
+	if entry.Time.IsZero() {
+		entry.Time = time.Now().UTC()
+	}
+
+	l.recent = append(l.recent, entry)
+	if len(l.recent) > recentEntries {
+		l.recent = slices.Delete(l.recent, 0, len(l.recent)-recentEntries)
+	}
+
+	if len(l.writers) == 0 {
+		return
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		log.Warn().Err(err).Str("executionId", entry.ExecutionId.String()).Msg("Failed to serialize audit log entry.")
+		return
+	}
+	line = append(line, '\n')
+	for _, w := range l.writers {
+		if _, err := w.Write(line); err != nil {
+			log.Warn().Err(err).Str("executionId", entry.ExecutionId.String()).Msg("Failed to write audit log entry.")
+		}
+	}
+}
+
+func (l *auditLog) page(executionId uuid.UUID, offset, limit int) Page {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var matching []Entry
+	for _, entry := range slices.Backward(l.recent) {
+		if executionId == uuid.Nil || entry.ExecutionId == executionId {
+			matching = append(matching, entry)
+		}
+	}
+
+	page := Page{Entries: []Entry{}, Total: len(matching), Offset: offset, Limit: limit}
+	if offset < len(matching) {
+		page.Entries = matching[offset:min(offset+limit, len(matching))]
+	}
+	return page
+}
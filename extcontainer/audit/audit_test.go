@@ -0,0 +1,146 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_rotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	file, err := openRotatingFile(path, 10, 2)
+	require.NoError(t, err)
+	defer func() { _ = file.Close() }()
+
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		_, err := file.Write([]byte(line))
+		require.NoError(t, err)
+	}
+
+	assertContent(t, path, "fourth\n")
+	assertContent(t, path+".1", "third\n")
+	assertContent(t, path+".2", "second\n")
+	assert.NoFileExists(t, path+".3")
+}
+
+func Test_rotatingFile_appendsToExistingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	require.NoError(t, os.WriteFile(path, []byte("before restart\n"), 0o600))
+
+	file, err := openRotatingFile(path, 20, 1)
+	require.NoError(t, err)
+	defer func() { _ = file.Close() }()
+
+	_, err = file.Write([]byte("after restart\n"))
+	require.NoError(t, err)
+
+	assertContent(t, path, "after restart\n")
+	assertContent(t, path+".1", "before restart\n")
+}
+
+func Test_auditLog_writesJsonLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	file, err := openRotatingFile(path, 0, 0)
+	require.NoError(t, err)
+	defer func() { _ = file.Close() }()
+
+	l := &auditLog{writers: []io.Writer{file}}
+	executionId := uuid.New()
+	l.record(Entry{Phase: PhasePrepare, Outcome: OutcomeSucceeded, ExecutionId: executionId, ActionId: "pause"})
+	l.record(Entry{Phase: PhaseStart, Outcome: OutcomeFailed, Error: "boom", ExecutionId: executionId, ActionId: "pause"})
+
+	content, err := os.Open(path)
+	require.NoError(t, err)
+	defer func() { _ = content.Close() }()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(content)
+	for scanner.Scan() {
+		var entry Entry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		entries = append(entries, entry)
+	}
+	require.Len(t, entries, 2)
+	assert.Equal(t, uint64(1), entries[0].Seq)
+	assert.Equal(t, PhaseStart, entries[1].Phase)
+	assert.Equal(t, "boom", entries[1].Error)
+	assert.False(t, entries[1].Time.IsZero())
+}
+
+func Test_Handler(t *testing.T) {
+	old := defaultLog
+	defaultLog = &auditLog{}
+	t.Cleanup(func() { defaultLog = old })
+
+	first, second := uuid.New(), uuid.New()
+	for i := 0; i < 3; i++ {
+		Record(Entry{Phase: PhaseStatus, ExecutionId: first})
+		Record(Entry{Phase: PhaseStatus, ExecutionId: second})
+	}
+
+	tests := []struct {
+		name      string
+		query     string
+		wantCode  int
+		wantSeqs  []uint64
+		wantTotal int
+	}{
+		{name: "newest first", query: "limit=2", wantCode: http.StatusOK, wantSeqs: []uint64{6, 5}, wantTotal: 6},
+		{name: "offset", query: "offset=4&limit=5", wantCode: http.StatusOK, wantSeqs: []uint64{2, 1}, wantTotal: 6},
+		{name: "execution", query: "executionId=" + first.String(), wantCode: http.StatusOK, wantSeqs: []uint64{5, 3, 1}, wantTotal: 3},
+		{name: "offset beyond", query: "offset=10", wantCode: http.StatusOK, wantSeqs: []uint64{}, wantTotal: 6},
+		{name: "invalid limit", query: "limit=-1", wantCode: http.StatusBadRequest},
+		{name: "invalid execution", query: "executionId=nope", wantCode: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			Handler(w, httptest.NewRequest(http.MethodGet, "/audit?"+tt.query, nil), nil)
+			require.Equal(t, tt.wantCode, w.Code)
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+
+			var page Page
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+			seqs := []uint64{}
+			for _, entry := range page.Entries {
+				seqs = append(seqs, entry.Seq)
+			}
+			assert.Equal(t, tt.wantSeqs, seqs)
+			assert.Equal(t, tt.wantTotal, page.Total)
+		})
+	}
+}
+
+func assertContent(t *testing.T, path, want string) {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, want, string(content))
+}
+
+func Test_RecordCommands(t *testing.T) {
+	// not collecting, nothing to record to
+	RecordCommands(t.Context(), "tc qdisc show")
+
+	ctx, commands := WithCommands(t.Context())
+	RecordCommands(ctx, "ip rule add blackhole to 10.0.0.0/8", "ip rule add blackhole from 10.0.0.0/8")
+	RecordCommands(ctx)
+	RecordCommands(context.WithoutCancel(ctx), "docker stop abc")
+
+	assert.Equal(t, []string{"ip rule add blackhole to 10.0.0.0/8", "ip rule add blackhole from 10.0.0.0/8", "docker stop abc"}, commands())
+}
@@ -0,0 +1,40 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package audit
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+type commandsKey struct{}
+
+// commandLog collects the commands executed using a context, the sidecars of an attack may run them concurrently
+type commandLog struct {
+	mu       sync.Mutex
+	commands []string
+}
+
+// WithCommands returns a context collecting the commands recorded using RecordCommands and a function returning the
+// commands collected so far.
+func WithCommands(ctx context.Context) (context.Context, func() []string) {
+	log := &commandLog{}
+	return context.WithValue(ctx, commandsKey{}, log), func() []string {
+		log.mu.Lock()
+		defer log.mu.Unlock()
+		return slices.Clone(log.commands)
+	}
+}
+
+// RecordCommands records the executed commands for the context, they are dropped if the context doesn't collect them
+func RecordCommands(ctx context.Context, commands ...string) {
+	log, ok := ctx.Value(commandsKey{}).(*commandLog)
+	if !ok || len(commands) == 0 {
+		return
+	}
+	log.mu.Lock()
+	defer log.mu.Unlock()
+	log.commands = append(log.commands, commands...)
+}
@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-container/extcontainer/audit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WithAudit_recordsLifecycle(t *testing.T) {
+	withTestJournal(t)
+	action := WithAudit[journalTestState](withJournal[journalTestState](&journalTestAction{}))
+	require.Implements(t, (*action_kit_sdk.ActionWithStatus[journalTestState])(nil), action)
+	require.Implements(t, (*action_kit_sdk.ActionWithStop[journalTestState])(nil), action)
+
+	executionId := uuid.New()
+	state := action.NewEmptyState()
+	_, err := action.Prepare(t.Context(), &state, action_kit_api.PrepareActionRequestBody{
+		ExecutionId: executionId,
+		Config:      map[string]any{"duration": 30000},
+		ExecutionContext: &action_kit_api.ExecutionContext{
+			ExperimentKey: new("ADM-1"),
+			ExecutionId:   new(42),
+		},
+	})
+	require.NoError(t, err)
+	_, err = action.Start(t.Context(), &state)
+	require.NoError(t, err)
+
+	// a running attack isn't recorded on every status call
+	_, err = action.(action_kit_sdk.ActionWithStatus[journalTestState]).Status(t.Context(), &state)
+	require.NoError(t, err)
+
+	_, err = action.(action_kit_sdk.ActionWithStop[journalTestState]).Stop(t.Context(), &state)
+	require.NoError(t, err)
+
+	page := audit.Recent(executionId, 0, 10)
+	require.Len(t, page.Entries, 3)
+	for i, phase := range []string{audit.PhaseStop, audit.PhaseStart, audit.PhasePrepare} {
+		entry := page.Entries[i]
+		assert.Equal(t, phase, entry.Phase)
+		assert.Equal(t, audit.OutcomeSucceeded, entry.Outcome)
+		assert.Equal(t, "journal-test", entry.ActionId)
+		assert.Equal(t, "container-1", entry.ContainerId)
+		assert.Equal(t, "ADM-1", entry.ExperimentKey)
+		assert.Equal(t, 42, entry.ExperimentExecutionId)
+	}
+	assert.Equal(t, []string{"revert container-1"}, page.Entries[0].Commands)
+	assert.Equal(t, []string{"apply container-1"}, page.Entries[1].Commands)
+	assert.Empty(t, page.Entries[2].Commands)
+	assert.Equal(t, map[string]any{"duration": 30000}, page.Entries[2].Parameters)
+	assert.JSONEq(t, `{"ExecutionId":"`+executionId.String()+`","ContainerId":"container-1","Applied":false}`, string(page.Entries[2].State))
+}
+
+func Test_WithAudit_expiresExperimentsNeverStarted(t *testing.T) {
+	withTestJournal(t)
+	action := WithAudit[journalTestState](withJournal[journalTestState](&journalTestAction{})).(*auditedActionWithStatusAndStop[journalTestState, *journalTestState])
+
+	abandoned, running := uuid.New(), uuid.New()
+	preparedAt := time.Now().Add(-guardPrepareTimeout - time.Second)
+	action.experiments.Store(abandoned, auditedExperiment{key: "ADM-1", preparedAt: preparedAt})
+	action.experiments.Store(running, auditedExperiment{key: "ADM-2", preparedAt: preparedAt, started: true})
+
+	executionId := uuid.New()
+	state := action.NewEmptyState()
+	_, err := action.Prepare(t.Context(), &state, action_kit_api.PrepareActionRequestBody{
+		ExecutionId:      executionId,
+		Config:           map[string]any{"duration": 30000},
+		ExecutionContext: &action_kit_api.ExecutionContext{ExperimentKey: new("ADM-3")},
+	})
+	require.NoError(t, err)
+
+	_, ok := action.experiments.Load(abandoned)
+	assert.False(t, ok, "experiments of attacks never started expire")
+	_, ok = action.experiments.Load(running)
+	assert.True(t, ok, "experiments of running attacks are kept")
+	_, ok = action.experiments.Load(executionId)
+	assert.True(t, ok)
+}
+
+func Test_WithAudit_keepsInterfaces(t *testing.T) {
+	signal := WithAudit[SignalActionState](NewSignalContainerAction(nil, nil))
+	assert.NotImplements(t, (*action_kit_sdk.ActionWithStatus[SignalActionState])(nil), signal)
+	assert.NotImplements(t, (*action_kit_sdk.ActionWithStop[SignalActionState])(nil), signal)
+
+	stop := WithAudit[StopActionState](NewStopContainerAction(nil))
+	assert.Implements(t, (*action_kit_sdk.ActionWithStatus[StopActionState])(nil), stop)
+	assert.Implements(t, (*action_kit_sdk.ActionWithStop[StopActionState])(nil), stop)
+}
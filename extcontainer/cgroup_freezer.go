@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+var cgroupRoot = "/sys/fs/cgroup"
+
+// freezeCGroup freezes (or thaws) all processes of the given cgroup using the cgroup freezer. This is what
+// `runc pause` does and is used for runtimes that don't offer pausing via their api (e.g. CRI-O).
+// It waits until the kernel reports the requested state.
+func freezeCGroup(ctx context.Context, cGroupPath string, frozen bool) error {
+	file, value, want, err := freezerFile(cGroupPath, frozen)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(file, []byte(value), 0644); err != nil {
+		return fmt.Errorf("failed to write %s to %s: %w", value, file, err)
+	}
+
+	for {
+		if state, err := readFreezerState(cGroupPath); err == nil && state == want {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("cgroup %s did not reach freezer state %t: %w", cGroupPath, frozen, ctx.Err())
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func freezerFile(cGroupPath string, frozen bool) (file, value string, want bool, err error) {
+	v2 := filepath.Join(cgroupRoot, cGroupPath, "cgroup.freeze")
+	if _, err := os.Stat(v2); err == nil {
+		if frozen {
+			return v2, "1", true, nil
+		}
+		return v2, "0", false, nil
+	}
+
+	v1 := filepath.Join(cgroupRoot, "freezer", cGroupPath, "freezer.state")
+	if _, err := os.Stat(v1); err == nil {
+		if frozen {
+			return v1, "FROZEN", true, nil
+		}
+		return v1, "THAWED", false, nil
+	}
+
+	return "", "", false, fmt.Errorf("no cgroup freezer found for %s", cGroupPath)
+}
+
+func readFreezerState(cGroupPath string) (bool, error) {
+	if events, err := os.ReadFile(filepath.Join(cgroupRoot, cGroupPath, "cgroup.events")); err == nil {
+		for _, line := range strings.Split(string(events), "\n") {
+			if value, ok := strings.CutPrefix(line, "frozen "); ok {
+				return strings.TrimSpace(value) == "1", nil
+			}
+		}
+		return false, errors.New("no frozen state in cgroup.events")
+	} else if !os.IsNotExist(err) {
+		return false, err
+	}
+
+	state, err := os.ReadFile(filepath.Join(cgroupRoot, "freezer", cGroupPath, "freezer.state"))
+	if err != nil {
+		return false, fmt.Errorf("failed to read freezer state of cgroup %s: %w", cGroupPath, err)
+	}
+	// FREEZING is reported while the freeze is in progress, so it is not frozen yet
+	return strings.TrimSpace(string(state)) == "FROZEN", nil
+}
@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func withCGroupRoot(t *testing.T) string {
+	old := cgroupRoot
+	cgroupRoot = t.TempDir()
+	t.Cleanup(func() { cgroupRoot = old })
+	return cgroupRoot
+}
+
+func Test_freezeCGroup_v2(t *testing.T) {
+	root := withCGroupRoot(t)
+	dir := filepath.Join(root, "kubepods.slice", "crio-abc.scope")
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "cgroup.freeze"), []byte("0"), 0644))
+	// the kernel updates cgroup.events, which we fake upfront
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "cgroup.events"), []byte("populated 1\nfrozen 1\n"), 0644))
+
+	require.NoError(t, freezeCGroup(t.Context(), "/kubepods.slice/crio-abc.scope", true))
+
+	content, err := os.ReadFile(filepath.Join(dir, "cgroup.freeze"))
+	require.NoError(t, err)
+	assert.Equal(t, "1", string(content))
+}
+
+func Test_freezeCGroup_v1(t *testing.T) {
+	root := withCGroupRoot(t)
+	dir := filepath.Join(root, "freezer", "kubepods", "crio-abc")
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "freezer.state"), []byte("FROZEN\n"), 0644))
+
+	require.NoError(t, freezeCGroup(t.Context(), "/kubepods/crio-abc", false))
+
+	content, err := os.ReadFile(filepath.Join(dir, "freezer.state"))
+	require.NoError(t, err)
+	assert.Equal(t, "THAWED", string(content))
+}
+
+func Test_freezeCGroup_missingCGroup(t *testing.T) {
+	withCGroupRoot(t)
+
+	err := freezeCGroup(t.Context(), "/kubepods/gone", true)
+	assert.ErrorContains(t, err, "no cgroup freezer found")
+}
@@ -34,6 +34,10 @@ const (
 	lossIcon           = "data:image/svg+xml,%3Csvg%20width%3D%2224%22%20height%3D%2224%22%20viewBox%3D%220%200%2024%2024%22%20fill%3D%22none%22%20xmlns%3D%22http%3A%2F%2Fwww.w3.org%2F2000%2Fsvg%22%3E%0A%3Cg%20clip-path%3D%22url%28%23clip0_1_36459%29%22%3E%0A%3Cpath%20d%3D%22M4.49998%203.375C4.42581%203.375%204.35331%203.39699%204.29164%203.4382C4.22997%203.4794%204.1819%203.53797%204.15352%203.60649C4.12514%203.67502%204.11771%203.75042%204.13218%203.82316C4.14665%203.8959%204.18237%203.96272%204.23481%204.01516C4.28726%204.06761%204.35408%204.10333%204.42682%204.11779C4.49956%204.13226%204.57496%204.12484%204.64348%204.09645C4.712%204.06807%204.77057%204.02001%204.81178%203.95834C4.85298%203.89667%204.87498%203.82417%204.87498%203.75C4.87498%203.65054%204.83547%203.55516%204.76514%203.48483C4.69482%203.41451%204.59943%203.375%204.49998%203.375Z%22%20fill%3D%22%231D2632%22%2F%3E%0A%3Cpath%20fill-rule%3D%22evenodd%22%20clip-rule%3D%22evenodd%22%20d%3D%22M3.87496%202.8146C4.05997%202.69098%204.27747%202.625%204.49998%202.625C4.79835%202.625%205.08449%202.74353%205.29547%202.9545C5.50645%203.16548%205.62498%203.45163%205.62498%203.75C5.62498%203.97251%205.559%204.19001%205.43538%204.37502C5.31176%204.56002%205.13606%204.70422%204.9305%204.78936C4.72493%204.87451%204.49873%204.89679%204.2805%204.85338C4.06227%204.80997%203.86181%204.70283%203.70448%204.5455C3.54715%204.38816%203.44%204.18771%203.39659%203.96948C3.35318%203.75125%203.37546%203.52505%203.46061%203.31948C3.54576%203.11392%203.68995%202.93821%203.87496%202.8146ZM4.64348%204.09645C4.66607%204.0871%204.68779%204.07551%204.70832%204.0618C4.74946%204.03431%204.78455%203.99909%204.81178%203.95834C4.82536%203.93801%204.83699%203.91631%204.84643%203.89351C4.86537%203.84779%204.87498%203.79901%204.87498%203.75C4.87498%203.72555%204.87259%203.70105%204.86777%203.67684C4.8533%203.6041%204.81758%203.53728%204.76514%203.48483C4.7127%203.43239%204.64588%203.39668%204.57314%203.38221C4.54893%203.37739%204.52442%203.375%204.49998%203.375C4.45097%203.375%204.40219%203.38461%204.35647%203.40354C4.33367%203.41299%204.31196%203.42462%204.29164%203.4382C4.25089%203.46543%204.21567%203.50052%204.18818%203.54166C4.17446%203.56218%204.16288%203.58391%204.15352%203.60649C4.13477%203.65177%204.12498%203.70051%204.12498%203.75C4.12498%203.77475%204.12742%203.79924%204.13218%203.82316C4.14665%203.8959%204.18237%203.96272%204.23481%204.01516C4.28726%204.06761%204.35408%204.10333%204.42682%204.11779C4.45074%204.12255%204.47523%204.125%204.49998%204.125C4.54946%204.125%204.5982%204.11521%204.64348%204.09645Z%22%20fill%3D%22%231D2632%22%2F%3E%0A%3Cpath%20fill-rule%3D%22evenodd%22%20clip-rule%3D%22evenodd%22%20d%3D%22M9.00098%203.75C9.00098%203.33579%209.33676%203%209.75098%203H11.251C11.6652%203%2012.001%203.33579%2012.001%203.75C12.001%204.16421%2011.6652%204.5%2011.251%204.5H9.75098C9.33676%204.5%209.00098%204.16421%209.00098%203.75Z%22%20fill%3D%22%231D2632%22%2F%3E%0A%3Cpath%20fill-rule%3D%22evenodd%22%20clip-rule%3D%22evenodd%22%20d%3D%22M13.501%203.75C13.501%203.33579%2013.8368%203%2014.251%203H15.751C16.1652%203%2016.501%203.33579%2016.501%203.75C16.501%204.16421%2016.1652%204.5%2015.751%204.5H14.251C13.8368%204.5%2013.501%204.16421%2013.501%203.75Z%22%20fill%3D%22%231D2632%22%2F%3E%0A%3Cpath%20d%3D%22M4.49998%209.375C4.42581%209.375%204.35331%209.39699%204.29164%209.4382C4.22997%209.4794%204.1819%209.53797%204.15352%209.60649C4.12514%209.67502%204.11771%209.75042%204.13218%209.82316C4.14665%209.8959%204.18237%209.96272%204.23481%2010.0152C4.28726%2010.0676%204.35408%2010.1033%204.42682%2010.1178C4.49956%2010.1323%204.57496%2010.1248%204.64348%2010.0965C4.712%2010.0681%204.77057%2010.02%204.81178%209.95834C4.85298%209.89667%204.87498%209.82417%204.87498%209.75C4.87498%209.65054%204.83547%209.55516%204.76514%209.48483C4.69482%209.41451%204.59943%209.375%204.49998%209.375Z%22%20fill%3D%22%231D2632%22%2F%3E%0A%3Cpath%20fill-rule%3D%22evenodd%22%20clip-rule%3D%22evenodd%22%20d%3D%22M3.87496%208.8146C4.05996%208.69098%204.27747%208.625%204.49998%208.625C4.79834%208.625%205.08449%208.74353%205.29547%208.9545C5.50645%209.16548%205.62498%209.45163%205.62498%209.75C5.62498%209.97251%205.559%2010.19%205.43538%2010.375C5.31176%2010.56%205.13606%2010.7042%204.9305%2010.7894C4.72493%2010.8745%204.49873%2010.8968%204.2805%2010.8534C4.06228%2010.81%203.86182%2010.7028%203.70448%2010.5455C3.54715%2010.3882%203.44%2010.1877%203.39659%209.96948C3.35318%209.75125%203.37546%209.52505%203.46061%209.31948C3.54576%209.11391%203.68995%208.93821%203.87496%208.8146ZM4.64348%2010.0965C4.66607%2010.0871%204.68779%2010.0755%204.70832%2010.0618C4.74946%2010.0343%204.78455%209.99909%204.81178%209.95834C4.82536%209.93801%204.83699%209.91631%204.84643%209.89351C4.86537%209.84779%204.87498%209.79901%204.87498%209.75C4.87498%209.72555%204.87259%209.70105%204.86777%209.67684C4.8533%209.6041%204.81758%209.53728%204.76514%209.48483C4.71269%209.43239%204.64587%209.39667%204.57314%209.38221C4.54893%209.37739%204.52442%209.375%204.49998%209.375C4.45097%209.375%204.40219%209.38461%204.35647%209.40355C4.33367%209.41299%204.31196%209.42462%204.29164%209.4382C4.25089%209.46543%204.21567%209.50052%204.18818%209.54166C4.17446%209.56218%204.16288%209.58391%204.15352%209.60649C4.13477%209.65177%204.12498%209.70051%204.12498%209.75C4.12498%209.77475%204.12742%209.79924%204.13218%209.82316C4.14665%209.8959%204.18237%209.96272%204.23481%2010.0152C4.28726%2010.0676%204.35408%2010.1033%204.42682%2010.1178C4.45074%2010.1226%204.47523%2010.125%204.49998%2010.125C4.54946%2010.125%204.5982%2010.1152%204.64348%2010.0965Z%22%20fill%3D%22%231D2632%22%2F%3E%0A%3Cpath%20fill-rule%3D%22evenodd%22%20clip-rule%3D%22evenodd%22%20d%3D%22M9.00098%209.75C9.00098%209.33579%209.33676%209%209.75098%209H11.251C11.6652%209%2012.001%209.33579%2012.001%209.75C12.001%2010.1642%2011.6652%2010.5%2011.251%2010.5H9.75098C9.33676%2010.5%209.00098%2010.1642%209.00098%209.75Z%22%20fill%3D%22%231D2632%22%2F%3E%0A%3Cpath%20fill-rule%3D%22evenodd%22%20clip-rule%3D%22evenodd%22%20d%3D%22M8.25098%2012C8.66519%2012%209.00098%2012.3358%209.00098%2012.75V18.75C9.00098%2019.1642%208.66519%2019.5%208.25098%2019.5H4.50098C4.08676%2019.5%203.75098%2019.1642%203.75098%2018.75C3.75098%2018.3358%204.08676%2018%204.50098%2018H7.50098V12.75C7.50098%2012.3358%207.83676%2012%208.25098%2012Z%22%20fill%3D%22%231D2632%22%2F%3E%0A%3Cpath%20d%3D%22M1.12598%2018C1.05181%2018%200.979306%2018.022%200.917638%2018.0632C0.855969%2018.1044%200.807905%2018.163%200.779522%2018.2315C0.751139%2018.3%200.743713%2018.3754%200.758182%2018.4482C0.772652%2018.5209%200.808367%2018.5877%200.860812%2018.6402C0.913256%2018.6926%200.980075%2018.7283%201.05282%2018.7428C1.12556%2018.7573%201.20096%2018.7498%201.26948%2018.7215C1.33801%2018.6931%201.39657%2018.645%201.43778%2018.5833C1.47898%2018.5217%201.50098%2018.4492%201.50098%2018.375C1.50098%2018.2755%201.46147%2018.1802%201.39114%2018.1098C1.32082%2018.0395%201.22543%2018%201.12598%2018Z%22%20fill%3D%22%231D2632%22%2F%3E%0A%3Cpath%20fill-rule%3D%22evenodd%22%20clip-rule%3D%22evenodd%22%20d%3D%22M3.75098%201.5C3.15424%201.5%202.58194%201.73705%202.15999%202.15901C1.73803%202.58097%201.50098%203.15326%201.50098%203.75C1.50098%204.34674%201.73803%204.91903%202.15999%205.34099C2.58194%205.76295%203.15424%206%203.75098%206H15.751C16.3477%206%2016.92%205.76295%2017.342%205.34099C17.7639%204.91903%2018.001%204.34674%2018.001%203.75C18.001%203.15326%2017.7639%202.58097%2017.342%202.15901C16.92%201.73705%2016.3477%201.5%2015.751%201.5H3.75098ZM1.09933%201.09835C1.80259%200.395088%202.75641%200%203.75098%200H15.751C16.7455%200%2017.6994%200.395088%2018.4026%201.09835C19.1059%201.80161%2019.501%202.75544%2019.501%203.75C19.501%204.74456%2019.1059%205.69839%2018.4026%206.40165C17.6994%207.10491%2016.7455%207.5%2015.751%207.5H3.75098C2.75641%207.5%201.80259%207.10491%201.09933%206.40165C0.396065%205.69839%200.000976562%204.74456%200.000976562%203.75C0.000976562%202.75544%200.396065%201.80161%201.09933%201.09835Z%22%20fill%3D%22%231D2632%22%2F%3E%0A%3Cpath%20fill-rule%3D%22evenodd%22%20clip-rule%3D%22evenodd%22%20d%3D%22M16.8773%207.80108C16.535%207.60359%2016.1462%207.49975%2015.751%207.5H3.75098C3.15424%207.5%202.58194%207.73705%202.15999%208.15901C1.73803%208.58097%201.50098%209.15326%201.50098%209.75C1.50098%2010.3467%201.73803%2010.919%202.15999%2011.341C2.58194%2011.7629%203.15424%2012%203.75098%2012H9.72998C10.1442%2012%2010.48%2012.3358%2010.48%2012.75C10.48%2013.1642%2010.1442%2013.5%209.72998%2013.5H3.75098C2.75641%2013.5%201.80259%2013.1049%201.09933%2012.4017C0.396065%2011.6984%200.000976562%2010.7446%200.000976562%209.75C0.000976562%208.75544%200.396065%207.80161%201.09933%207.09835C1.80259%206.39509%202.75641%206%203.75098%206H15.751C15.7509%206%2015.751%206%2015.751%206C16.4095%205.99966%2017.0565%206.17273%2017.6269%206.5018C18.1974%206.83096%2018.6712%207.30457%2019.0005%207.875C19.2076%208.23372%2019.0847%208.69241%2018.726%208.89952C18.3673%209.10663%2017.9086%208.98372%2017.7015%208.625C17.5039%208.28274%2017.2196%207.99857%2016.8773%207.80108Z%22%20fill%3D%22%231D2632%22%2F%3E%0A%3Cpath%20fill-rule%3D%22evenodd%22%20clip-rule%3D%22evenodd%22%20d%3D%22M17.251%2012C14.3515%2012%2012.001%2014.3505%2012.001%2017.25C12.001%2020.1495%2014.3515%2022.5%2017.251%2022.5C20.1505%2022.5%2022.501%2020.1495%2022.501%2017.25C22.501%2014.3505%2020.1505%2012%2017.251%2012ZM10.501%2017.25C10.501%2013.5221%2013.5231%2010.5%2017.251%2010.5C20.9789%2010.5%2024.001%2013.5221%2024.001%2017.25C24.001%2020.9779%2020.9789%2024%2017.251%2024C13.5231%2024%2010.501%2020.9779%2010.501%2017.25Z%22%20fill%3D%22%231D2632%22%2F%3E%0A%3Cpath%20fill-rule%3D%22evenodd%22%20clip-rule%3D%22evenodd%22%20d%3D%22M20.0313%2014.4687C20.3242%2014.7616%2020.3242%2015.2364%2020.0313%2015.5293L15.5313%2020.0293C15.2384%2020.3222%2014.7635%2020.3222%2014.4706%2020.0293C14.1778%2019.7364%2014.1778%2019.2616%2014.4706%2018.9687L18.9706%2014.4687C19.2635%2014.1758%2019.7384%2014.1758%2020.0313%2014.4687Z%22%20fill%3D%22%231D2632%22%2F%3E%0A%3Cpath%20fill-rule%3D%22evenodd%22%20clip-rule%3D%22evenodd%22%20d%3D%22M14.4706%2014.4687C14.7635%2014.1758%2015.2384%2014.1758%2015.5313%2014.4687L20.0313%2018.9687C20.3242%2019.2616%2020.3242%2019.7364%2020.0313%2020.0293C19.7384%2020.3222%2019.2635%2020.3222%2018.9706%2020.0293L14.4706%2015.5293C14.1778%2015.2364%2014.1778%2014.7616%2014.4706%2014.4687Z%22%20fill%3D%22%231D2632%22%2F%3E%0A%3C%2Fg%3E%0A%3Cdefs%3E%0A%3CclipPath%20id%3D%22clip0_1_36459%22%3E%0A%3Crect%20width%3D%2224%22%20height%3D%2224%22%20fill%3D%22white%22%2F%3E%0A%3C%2FclipPath%3E%0A%3C%2Fdefs%3E%0A%3C%2Fsvg%3E%0A"
 	fillDiskIcon       = "data:image/svg+xml,%3Csvg%20width%3D%2224%22%20height%3D%2224%22%20viewBox%3D%220%200%2024%2024%22%20fill%3D%22none%22%20xmlns%3D%22http%3A%2F%2Fwww.w3.org%2F2000%2Fsvg%22%3E%0A%3Cg%20clip-path%3D%22url%28%23clip0_2810_382%29%22%3E%0A%3Cpath%20fill-rule%3D%22evenodd%22%20clip-rule%3D%22evenodd%22%20d%3D%22M16.26%202.09L17.53%207.32H17.54C17.64%207.73%2017.39%208.13%2016.99%208.23C16.58%208.33%2016.18%208.08%2016.08%207.68L14.81%202.45C14.67%201.9%2014.18%201.51%2013.61%201.51H5.23C4.66%201.51%204.16%201.9%204.03%202.45L2.31%209.53C2.78%209.33%203.3%209.21%203.85%209.21H13.34C13.75%209.21%2014.09%209.55%2014.09%209.96C14.09%2010.37%2013.75%2010.71%2013.34%2010.71H3.85C2.88%2010.71%202.04%2011.3%201.68%2012.14L1.51%2012.83C1.51%2012.87%201.505%2012.91%201.5%2012.95C1.495%2012.99%201.49%2013.03%201.49%2013.07V13.46C1.49%2014.76%202.55%2015.82%203.85%2015.82H9.9C10.31%2015.82%2010.65%2016.16%2010.65%2016.57C10.65%2016.98%2010.31%2017.32%209.9%2017.32H3.86C1.73%2017.32%200%2015.59%200%2013.46V13.07C0%2013.0187%200.00790022%2012.97%200.0155924%2012.9226C0.0228898%2012.8776%200.03%2012.8338%200.03%2012.79C0.03%2012.7659%200.0276244%2012.7429%200.0253294%2012.7208C0.0209654%2012.6786%200.0168929%2012.6393%200.03%2012.6L0.05%2012.52C0.08%2012.27%200.14%2012.03%200.22%2011.8L2.58%202.09C2.87%200.86%203.97%200%205.23%200H13.61C14.87%200%2015.96%200.86%2016.26%202.09ZM15.56%2010.8C16.07%209.86%2017.47%209.86%2017.98%2010.8L23.69%2021.29C24.17%2022.17%2023.51%2023.23%2022.48%2023.23H11.07C10.04%2023.23%209.38%2022.17%209.86%2021.29L15.57%2010.8H15.56ZM22.48%2021.91L16.77%2011.42L11.06%2021.91H22.47H22.48ZM16.09%2017.28C16.09%2017.64%2016.39%2017.94%2016.77%2017.94C17.14%2017.94%2017.45%2017.65%2017.45%2017.28V15.29C17.45%2014.93%2017.14%2014.63%2016.77%2014.63C16.4%2014.63%2016.09%2014.92%2016.09%2015.29V17.28ZM16.77%2018.6C16.2%2018.6%2015.74%2019.04%2015.74%2019.59C15.74%2020.14%2016.2%2020.58%2016.77%2020.58C17.34%2020.58%2017.8%2020.14%2017.8%2019.59C17.8%2019.04%2017.34%2018.6%2016.77%2018.6ZM4.32%2012.48C3.91%2012.48%203.57%2012.82%203.57%2013.23C3.57%2013.64%203.91%2013.98%204.32%2013.98H8.37C8.78%2013.98%209.12%2013.64%209.12%2013.23C9.12%2012.82%208.78%2012.48%208.37%2012.48H4.32ZM12.42%2013.24C12.42%2013.7868%2011.9589%2014.23%2011.39%2014.23C10.8211%2014.23%2010.36%2013.7868%2010.36%2013.24C10.36%2012.6932%2010.8211%2012.25%2011.39%2012.25C11.9589%2012.25%2012.42%2012.6932%2012.42%2013.24Z%22%20fill%3D%22%231D2632%22%2F%3E%0A%3C%2Fg%3E%0A%3Cdefs%3E%0A%3CclipPath%20id%3D%22clip0_2810_382%22%3E%0A%3Crect%20width%3D%2224%22%20height%3D%2224%22%20fill%3D%22white%22%2F%3E%0A%3C%2FclipPath%3E%0A%3C%2Fdefs%3E%0A%3C%2Fsvg%3E%0A"
 	fillMemoryIcon     = "data:image/svg+xml,%3Csvg%20width%3D%2224%22%20height%3D%2224%22%20viewBox%3D%220%200%2024%2024%22%20fill%3D%22none%22%20xmlns%3D%22http%3A%2F%2Fwww.w3.org%2F2000%2Fsvg%22%3E%0A%3Cpath%20fill-rule%3D%22evenodd%22%20clip-rule%3D%22evenodd%22%20d%3D%22M17.1063%201.49823C16.9943%201.49823%2016.8834%201.52037%2016.7799%201.56338C16.6765%201.6064%2016.5826%201.66943%2016.5036%201.74886L16.5019%201.75054L10.5432%207.70453L10.5609%207.78931C10.6379%208.16975%2010.6196%208.56331%2010.5077%208.93498C10.3958%209.30665%2010.1938%209.64491%209.91967%209.91966C9.6455%2010.1944%209.30767%2010.3971%208.93624%2010.5098C8.56481%2010.6225%208.17129%2010.6416%207.79069%2010.5654L7.78392%2010.5641L7.70419%2010.5473L1.75019%2016.5023L1.74867%2016.5038C1.66924%2016.5828%201.60621%2016.6767%201.5632%2016.7801C1.52019%2016.8836%201.49805%2016.9945%201.49805%2017.1065C1.49805%2017.2185%201.52019%2017.3294%201.5632%2017.4329C1.60621%2017.5363%201.66924%2017.6302%201.74867%2017.7092L1.75015%2017.7107L6.29061%2022.2511C6.3696%2022.3306%206.46351%2022.3936%206.56695%2022.4366C6.67038%2022.4796%206.78129%2022.5018%206.89331%2022.5018C7.00534%2022.5018%207.11625%2022.4796%207.21968%2022.4366C7.32312%2022.3936%207.41703%2022.3306%207.49602%2022.2511L7.49748%2022.2497L22.2495%207.49767L22.251%207.4962C22.3304%207.41721%2022.3934%207.3233%2022.4364%207.21987C22.4794%207.11644%2022.5016%207.00552%2022.5016%206.8935C22.5016%206.78147%2022.4794%206.67056%2022.4364%206.56713C22.3934%206.4637%2022.3304%206.36978%2022.251%206.29079L22.2495%206.28933L17.7105%201.75033L17.709%201.74886C17.63%201.66943%2017.5361%201.60639%2017.4327%201.56338C17.3292%201.52037%2017.2183%201.49823%2017.1063%201.49823ZM16.204%200.178361C16.49%200.0594469%2016.7966%20-0.00177002%2017.1063%20-0.00177002C17.416%20-0.00177002%2017.7227%200.0594468%2018.0086%200.178361C18.2942%200.297124%2018.5536%200.4711%2018.7718%200.690304L18.7726%200.691138L23.3087%205.2272L23.3094%205.22795C23.5287%205.44618%2023.7027%205.70555%2023.8214%205.99119C23.9404%206.27715%2024.0016%206.5838%2024.0016%206.8935C24.0016%207.2032%2023.9404%207.50984%2023.8214%207.79581C23.7027%208.08145%2023.5287%208.34082%2023.3094%208.55905L23.3087%208.55979L8.55961%2023.3089L8.55886%2023.3096C8.34063%2023.5289%208.08126%2023.7029%207.79563%2023.8216C7.50966%2023.9405%207.20301%2024.0018%206.89331%2024.0018C6.58362%2024.0018%206.27697%2023.9405%205.991%2023.8216C5.70537%2023.7029%205.446%2023.5289%205.22777%2023.3096L5.22702%2023.3089L0.690955%2018.7728L0.690121%2018.772C0.470917%2018.5538%200.296941%2018.2944%200.178177%2018.0088C0.0592636%2017.7228%20-0.00195312%2017.4162%20-0.00195312%2017.1065C-0.00195312%2016.7968%200.0592638%2016.4901%200.178177%2016.2042C0.296919%2015.9186%200.470851%2015.6593%200.689999%2015.4412L0.690955%2015.4402L6.93044%209.19971C7.1095%209.02062%207.36684%208.94399%207.6147%208.99595L8.08778%209.09513C8.22508%209.12212%208.36692%209.115%208.50084%209.07437C8.6357%209.03347%208.75835%208.95987%208.85789%208.86012C8.95742%208.76037%209.03076%208.63756%209.07138%208.50263C9.11179%208.36839%209.11857%208.22629%209.09113%208.08884L8.99177%207.61488C8.93979%207.36694%209.01649%207.10952%209.1957%206.93045L15.44%200.691138L15.4411%200.690074C15.6592%200.470977%2015.9185%200.297082%2016.204%200.178361Z%22%20fill%3D%22%231D2632%22%2F%3E%0A%3Cpath%20fill-rule%3D%22evenodd%22%20clip-rule%3D%22evenodd%22%20d%3D%22M7.49725%2015.4419C7.79001%2015.1489%208.26489%2015.1487%208.55791%2015.4414L9.69291%2016.5754C9.83364%2016.716%209.91275%2016.9068%209.91281%2017.1058C9.91288%2017.3047%209.8339%2017.4955%209.69326%2017.6362L7.42426%2019.9062C7.28362%2020.0469%207.09284%2020.126%206.8939%2020.126C6.69496%2020.126%206.50416%2020.047%206.36348%2019.9063L5.22848%2018.7713C4.93559%2018.4784%204.93559%2018.0036%205.22848%2017.7107C5.52138%2017.4178%205.99625%2017.4178%206.28914%2017.7107L6.8937%2018.3152L8.10204%2017.1063L7.49772%2016.5026C7.2047%2016.2098%207.20449%2015.7349%207.49725%2015.4419Z%22%20fill%3D%22%231D2632%22%2F%3E%0A%3Cpath%20fill-rule%3D%22evenodd%22%20clip-rule%3D%22evenodd%22%20d%3D%22M17.7105%205.22867C18.0034%204.93577%2018.4782%204.93577%2018.7711%205.22867L19.9061%206.36367C20.0468%206.50434%2020.1258%206.69514%2020.1258%206.89408C20.1258%207.09302%2020.0467%207.2838%2019.906%207.42444L17.636%209.69344C17.4953%209.83409%2017.3045%209.91306%2017.1056%209.913C16.9066%209.91293%2016.7159%209.83383%2016.5753%209.69309L15.4413%208.55809C15.1485%208.26507%2015.1487%207.7902%2015.4417%207.49743C15.7347%207.20467%2016.2096%207.20488%2016.5024%207.4979L17.1062%208.10222L18.315%206.89388L17.7105%206.28933C17.4176%205.99643%2017.4176%205.52156%2017.7105%205.22867Z%22%20fill%3D%22%231D2632%22%2F%3E%0A%3Cpath%20fill-rule%3D%22evenodd%22%20clip-rule%3D%22evenodd%22%20d%3D%22M13.1715%209.76767C13.4644%209.47477%2013.9392%209.47477%2014.2321%209.76767L15.3671%2010.9027C15.5078%2011.0433%2015.5868%2011.2341%2015.5868%2011.433C15.5868%2011.6319%2015.5078%2011.8227%2015.3671%2011.9633L11.9631%2015.3673C11.8225%2015.508%2011.6317%2015.587%2011.4328%2015.587C11.2339%2015.587%2011.0431%2015.508%2010.9025%2015.3673L9.76748%2014.2323C9.47459%2013.9394%209.47459%2013.4646%209.76748%2013.1717C10.0604%2012.8788%2010.5353%2012.8788%2010.8281%2013.1717L11.4328%2013.7763L13.7762%2011.433L13.1715%2010.8283C12.8786%2010.5354%2012.8786%2010.0606%2013.1715%209.76767Z%22%20fill%3D%22%231D2632%22%2F%3E%0A%3Cpath%20fill-rule%3D%22evenodd%22%20clip-rule%3D%22evenodd%22%20d%3D%22M1.82472%2014.3064C2.11774%2014.0137%202.59261%2014.0139%202.88538%2014.3069L4.01938%2015.4419C4.31214%2015.7349%204.31193%2016.2098%204.01891%2016.5026C3.72589%2016.7953%203.25101%2016.7951%202.95825%2016.5021L1.82425%2015.3671C1.53149%2015.0741%201.5317%2014.5992%201.82472%2014.3064Z%22%20fill%3D%22%231D2632%22%2F%3E%0A%3Cpath%20fill-rule%3D%22evenodd%22%20clip-rule%3D%22evenodd%22%20d%3D%22M4.09348%2012.0367C4.38638%2011.7438%204.86125%2011.7438%205.15414%2012.0367L6.28914%2013.1717C6.58204%2013.4646%206.58204%2013.9394%206.28914%2014.2323C5.99625%2014.5252%205.52138%2014.5252%205.22848%2014.2323L4.09348%2013.0973C3.80059%2012.8044%203.80059%2012.3296%204.09348%2012.0367Z%22%20fill%3D%22%231D2632%22%2F%3E%0A%3Cpath%20fill-rule%3D%22evenodd%22%20clip-rule%3D%22evenodd%22%20d%3D%22M12.0365%204.09367C12.3294%203.80077%2012.8043%203.80077%2013.0971%204.09367L14.2321%205.22867C14.525%205.52156%2014.525%205.99643%2014.2321%206.28933C13.9392%206.58222%2013.4644%206.58222%2013.1715%206.28933L12.0365%205.15433C11.7436%204.86143%2011.7436%204.38656%2012.0365%204.09367Z%22%20fill%3D%22%231D2632%22%2F%3E%0A%3Cpath%20fill-rule%3D%22evenodd%22%20clip-rule%3D%22evenodd%22%20d%3D%22M14.3063%201.8249C14.599%201.53188%2015.0739%201.53167%2015.3669%201.82443L16.5019%202.95843C16.7949%203.2512%2016.7951%203.72607%2016.5024%204.01909C16.2096%204.31212%2015.7347%204.31232%2015.4417%204.01956L14.3067%202.88556C14.0137%202.5928%2014.0135%202.11792%2014.3063%201.8249Z%22%20fill%3D%22%231D2632%22%2F%3E%0A%3C%2Fsvg%3E%0A"
+	restartIcon        = "data:image/svg+xml,%3Csvg%20width='24'%20height='24'%20viewBox='0%200%2024%2024'%20fill='none'%20xmlns='http://www.w3.org/2000/svg'%3E%3Cpath%20d='M20.5%204v5.5H15M3.5%2020v-5.5H9'%20stroke='currentcolor'%20stroke-width='2'%20stroke-linecap='round'%20stroke-linejoin='round'/%3E%3Cpath%20d='M5.79%208.5a7.5%207.5%200%200%201%2013.36-1.32l1.35%202.32M3.5%2014.5l1.35%202.32A7.5%207.5%200%200%200%2018.21%2015.5'%20stroke='currentcolor'%20stroke-width='2'%20stroke-linecap='round'%20stroke-linejoin='round'/%3E%3C/svg%3E"
+	signalIcon         = "data:image/svg+xml,%3Csvg%20width='24'%20height='24'%20viewBox='0%200%2024%2024'%20fill='none'%20xmlns='http://www.w3.org/2000/svg'%3E%3Cpath%20d='M13%202L4%2014h7l-1%208%209-12h-7l1-8z'%20stroke='currentcolor'%20stroke-width='2'%20stroke-linecap='round'%20stroke-linejoin='round'/%3E%3C/svg%3E"
+	killProcessIcon    = "data:image/svg+xml,%3Csvg%20width='24'%20height='24'%20viewBox='0%200%2024%2024'%20fill='none'%20xmlns='http://www.w3.org/2000/svg'%3E%3Crect%20x='3'%20y='4'%20width='18'%20height='16'%20rx='2'%20stroke='currentcolor'%20stroke-width='2'/%3E%3Cpath%20d='M9%209l6%206M15%209l-6%206'%20stroke='currentcolor'%20stroke-width='2'%20stroke-linecap='round'/%3E%3C/svg%3E"
+	partitionIcon      = "data:image/svg+xml,%3Csvg%20width='24'%20height='24'%20viewBox='0%200%2024%2024'%20fill='none'%20xmlns='http://www.w3.org/2000/svg'%3E%3Crect%20x='2'%20y='8'%20width='7'%20height='8'%20rx='1'%20stroke='currentcolor'%20stroke-width='2'/%3E%3Crect%20x='15'%20y='8'%20width='7'%20height='8'%20rx='1'%20stroke='currentcolor'%20stroke-width='2'/%3E%3Cpath%20d='M12%203v18'%20stroke='currentcolor'%20stroke-width='2'%20stroke-linecap='round'%20stroke-dasharray='2%203'/%3E%3C/svg%3E"
 
 	separator = "://"
 )
@@ -77,6 +81,14 @@ func AddPrefix(containerId string, runtime types.Runtime) string {
 	return containerId
 }
 
+// containerRuntime returns the runtime of the container, which is the runtime of the client unless it spans several
+func containerRuntime(client types.Client, container types.Container) types.Runtime {
+	if rc, ok := container.(types.RuntimeContainer); ok {
+		return rc.Runtime()
+	}
+	return client.Runtime()
+}
+
 func getContainerTarget(ctx context.Context, client types.Client, target action_kit_api.Target) (types.Container, string, error) {
 	containerId := target.Attributes["container.id"]
 	if len(containerId) == 0 {
@@ -92,6 +104,14 @@ func getContainerTarget(ctx context.Context, client types.Client, target action_
 		return nil, "", extension_kit.ToError("Container is in a namespace disallowed for attacks", nil)
 	}
 
+	if rule, ok := excludingRule(client, container); ok {
+		return nil, "", extension_kit.ToError(fmt.Sprintf("Container is excluded from attacks by rule %q", rule), nil)
+	}
+
+	if rule, ok := disallowingRule(client, container); ok {
+		return nil, "", extension_kit.ToError(fmt.Sprintf("Attacks on container %s are disallowed by rule %q", strings.TrimPrefix(container.Name(), "/"), rule), nil)
+	}
+
 	label := container.Id()
 	if len(target.Attributes["steadybit.label"]) > 0 {
 		label = fmt.Sprintf("%s (%s)", target.Attributes["steadybit.label"][0], RemovePrefix(container.Id())[0:8])
@@ -10,6 +10,7 @@ import (
 	"github.com/steadybit/extension-container/extcontainer/container/types"
 	extension_kit "github.com/steadybit/extension-kit"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"os"
 	"testing"
 )
@@ -27,6 +28,7 @@ func Test_addPrefix(t *testing.T) {
 		{"containerd", args{containerId: "test", runtime: types.RuntimeContainerd}, "containerd://test"},
 		{"docker", args{containerId: "test", runtime: types.RuntimeDocker}, "docker://test"},
 		{"cri-o", args{containerId: "test", runtime: types.RuntimeCrio}, "cri-o://test"},
+		{"podman", args{containerId: "test", runtime: types.RuntimePodman}, "podman://test"},
 		{"already has prefix", args{containerId: "docker://test", runtime: types.RuntimeDocker}, "docker://test"},
 	}
 	for _, tt := range tests {
@@ -114,3 +116,49 @@ func Test_getContainerTarget(t *testing.T) {
 		})
 	}
 }
+
+func Test_getContainerTarget_excluded(t *testing.T) {
+	require.NoError(t, config.Config.ExcludeContainers.Decode("label:app=sidecar"))
+	defer func() { config.Config.ExcludeContainers = nil }()
+
+	client := &watchingClient{MockedClient: newMockedContainerClient().
+		addContainer("web", map[string]string{"app": "web"}).
+		addContainer("proxy", map[string]string{"app": "sidecar"}),
+	}
+
+	_, _, err := getContainerTarget(t.Context(), client, action_kit_api.Target{
+		Attributes: map[string][]string{"container.id": {"docker://web"}},
+	})
+	assert.NoError(t, err)
+
+	_, _, err = getContainerTarget(t.Context(), client, action_kit_api.Target{
+		Attributes: map[string][]string{"container.id": {"docker://proxy"}},
+	})
+	assert.Equal(t, extension_kit.ToError(`Container is excluded from attacks by rule "label:app=sidecar"`, nil), err)
+}
+
+func Test_getContainerTarget_disallowed(t *testing.T) {
+	require.NoError(t, config.Config.DisallowContainers.Decode("image=mocked-image-*; label:tier=storage AND name=mocked-db"))
+	defer func() { config.Config.DisallowContainers = nil }()
+
+	client := &watchingClient{MockedClient: newMockedContainerClient().
+		addContainer("db", map[string]string{"tier": "storage"}).
+		addContainer("cache", map[string]string{"tier": "storage"}),
+	}
+
+	_, _, err := getContainerTarget(t.Context(), client, action_kit_api.Target{
+		Attributes: map[string][]string{"container.id": {"docker://db"}},
+	})
+	assert.Equal(t, extension_kit.ToError(`Attacks on container mocked-db are disallowed by rule "image=mocked-image-*"`, nil), err)
+
+	config.Config.DisallowContainers = config.Config.DisallowContainers[1:]
+	_, _, err = getContainerTarget(t.Context(), client, action_kit_api.Target{
+		Attributes: map[string][]string{"container.id": {"docker://db"}},
+	})
+	assert.Equal(t, extension_kit.ToError(`Attacks on container mocked-db are disallowed by rule "label:tier=storage AND name=mocked-db"`, nil), err)
+
+	_, _, err = getContainerTarget(t.Context(), client, action_kit_api.Target{
+		Attributes: map[string][]string{"container.id": {"docker://cache"}},
+	})
+	assert.NoError(t, err)
+}
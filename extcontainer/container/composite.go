@@ -0,0 +1,258 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+)
+
+// idSeparator separates the runtime from the container id, e.g. containerd://abc
+const idSeparator = "://"
+
+// compositeClient combines the clients of several runtimes. Listing fans out to all runtimes, all other calls are
+// routed to the runtime owning the container. Ids prefixed with the runtime are routed by the prefix, plain ids to the
+// runtime which listed the container last or else to the first runtime knowing it.
+type compositeClient struct {
+	clients []types.Client
+
+	mu     sync.RWMutex
+	owners map[string]types.Client
+}
+
+// runtimeContainer is a container listed by the composite client, tagged with its runtime
+type runtimeContainer struct {
+	types.Container
+	runtime types.Runtime
+	version string
+}
+
+func (c runtimeContainer) Runtime() types.Runtime {
+	return c.runtime
+}
+
+func (c runtimeContainer) RuntimeVersion() string {
+	return c.version
+}
+
+func (c runtimeContainer) Namespace() string {
+	if namespaced, ok := c.Container.(types.NamespacedContainer); ok {
+		return namespaced.Namespace()
+	}
+	return ""
+}
+
+func newCompositeClient(clients ...types.Client) *compositeClient {
+	return &compositeClient{clients: clients, owners: map[string]types.Client{}}
+}
+
+func (c *compositeClient) Runtimes() []types.Runtime {
+	result := make([]types.Runtime, 0, len(c.clients))
+	for _, client := range c.clients {
+		result = append(result, client.Runtime())
+	}
+	return result
+}
+
+// Runtime returns the first runtime, each container tells its own runtime
+func (c *compositeClient) Runtime() types.Runtime {
+	return c.clients[0].Runtime()
+}
+
+func (c *compositeClient) Socket() string {
+	sockets := make([]string, 0, len(c.clients))
+	for _, client := range c.clients {
+		sockets = append(sockets, client.Socket())
+	}
+	return strings.Join(sockets, ",")
+}
+
+// List lists the containers of all runtimes. Runtimes failing to list their containers are skipped, unless all fail.
+// A container listed by several runtimes (e.g. a Docker container in the moby namespace of containerd) is owned by the
+// first of them.
+func (c *compositeClient) List(ctx context.Context) ([]types.Container, error) {
+	var result []types.Container
+	var errs []error
+	owners := map[string]types.Client{}
+	for _, client := range c.clients {
+		containers, err := client.List(ctx)
+		if err != nil {
+			log.Warn().Err(err).Str("runtime", string(client.Runtime())).Msg("Failed to list containers of runtime.")
+			errs = append(errs, fmt.Errorf("%s: %w", client.Runtime(), err))
+			continue
+		}
+
+		version, _ := client.Version(ctx)
+		for _, container := range containers {
+			if owner, ok := owners[container.Id()]; ok {
+				log.Debug().Str("containerId", container.Id()).Str("runtime", string(owner.Runtime())).Msgf("Container is also listed by %s, ignoring it there.", client.Runtime())
+				continue
+			}
+			owners[container.Id()] = client
+			result = append(result, runtimeContainer{Container: container, runtime: client.Runtime(), version: version})
+		}
+	}
+	if len(errs) == len(c.clients) {
+		return nil, errors.Join(errs...)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.owners = owners
+	return result, nil
+}
+
+func (c *compositeClient) Info(ctx context.Context, id string) (types.Container, error) {
+	client, id, err := c.route(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	container, err := client.Info(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	version, _ := client.Version(ctx)
+	return runtimeContainer{Container: container, runtime: client.Runtime(), version: version}, nil
+}
+
+func (c *compositeClient) GetPid(ctx context.Context, id string) (int, error) {
+	client, id, err := c.route(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	return client.GetPid(ctx, id)
+}
+
+func (c *compositeClient) Pause(ctx context.Context, id string) error {
+	client, id, err := c.route(ctx, id)
+	if err != nil {
+		return err
+	}
+	return client.Pause(ctx, id)
+}
+
+func (c *compositeClient) Unpause(ctx context.Context, id string) error {
+	client, id, err := c.route(ctx, id)
+	if err != nil {
+		return err
+	}
+	return client.Unpause(ctx, id)
+}
+
+func (c *compositeClient) Stop(ctx context.Context, id string, graceful bool, gracePeriod time.Duration) (bool, error) {
+	client, id, err := c.route(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	return client.Stop(ctx, id, graceful, gracePeriod)
+}
+
+func (c *compositeClient) Restart(ctx context.Context, id string, graceful bool, timeout time.Duration) error {
+	client, id, err := c.route(ctx, id)
+	if err != nil {
+		return err
+	}
+	return client.Restart(ctx, id, graceful, timeout)
+}
+
+// Version returns the versions of all runtimes, it fails if any of the runtimes is unavailable
+func (c *compositeClient) Version(ctx context.Context) (string, error) {
+	versions := make([]string, 0, len(c.clients))
+	for _, client := range c.clients {
+		version, err := client.Version(ctx)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", client.Runtime(), err)
+		}
+		versions = append(versions, fmt.Sprintf("%s %s", client.Runtime(), version))
+	}
+	return strings.Join(versions, ", "), nil
+}
+
+// Watch merges the events of all runtimes. The channel is closed when any of the streams breaks, so no events go
+// unnoticed until the caller watches again.
+func (c *compositeClient) Watch(ctx context.Context) (<-chan types.Event, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	var streams []<-chan types.Event
+	for _, client := range c.clients {
+		events, err := client.Watch(ctx)
+		if err != nil {
+			cancel()
+			return nil, fmt.Errorf("%s: %w", client.Runtime(), err)
+		}
+		streams = append(streams, events)
+	}
+
+	result := make(chan types.Event)
+	var wg sync.WaitGroup
+	for i, events := range streams {
+		client := c.clients[i]
+		wg.Go(func() {
+			defer cancel()
+			for event := range events {
+				if event.Type == types.EventTypeCreate || event.Type == types.EventTypeStart {
+					c.setOwner(event.ContainerId, client)
+				}
+				select {
+				case result <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		})
+	}
+	go func() {
+		wg.Wait()
+		cancel()
+		close(result)
+	}()
+	return result, nil
+}
+
+func (c *compositeClient) Close() error {
+	var errs []error
+	for _, client := range c.clients {
+		errs = append(errs, client.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// route returns the client of the runtime owning the container and the id without the runtime prefix
+func (c *compositeClient) route(ctx context.Context, id string) (types.Client, string, error) {
+	if runtime, plainId, ok := strings.Cut(id, idSeparator); ok {
+		for _, client := range c.clients {
+			if string(client.Runtime()) == runtime {
+				return client, plainId, nil
+			}
+		}
+		return nil, "", fmt.Errorf("container runtime %s is not configured", runtime)
+	}
+
+	c.mu.RLock()
+	client, ok := c.owners[id]
+	c.mu.RUnlock()
+	if ok {
+		return client, id, nil
+	}
+
+	for _, client := range c.clients {
+		if _, err := client.Info(ctx, id); err == nil {
+			c.setOwner(id, client)
+			return client, id, nil
+		}
+	}
+	return nil, "", fmt.Errorf("container %s not found in any of the runtimes %v", id, c.Runtimes())
+}
+
+func (c *compositeClient) setOwner(id string, client types.Client) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.owners[id] = client
+}
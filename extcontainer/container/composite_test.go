@@ -0,0 +1,177 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeContainer struct {
+	id string
+}
+
+func (c fakeContainer) Id() string                 { return c.id }
+func (c fakeContainer) Name() string               { return c.id }
+func (c fakeContainer) ImageName() string          { return "image" }
+func (c fakeContainer) Labels() map[string]string  { return nil }
+func (c fakeContainer) Networks() []types.Network  { return nil }
+func (c fakeContainer) Ports() []types.Port        { return nil }
+func (c fakeContainer) Lifecycle() types.Lifecycle { return types.Lifecycle{} }
+
+type fakeRuntimeClient struct {
+	types.Client
+	runtime    types.Runtime
+	containers []string
+	listErr    error
+	events     chan types.Event
+	paused     []string
+}
+
+func (c *fakeRuntimeClient) Runtime() types.Runtime {
+	return c.runtime
+}
+
+func (c *fakeRuntimeClient) Version(_ context.Context) (string, error) {
+	return "1.0", nil
+}
+
+func (c *fakeRuntimeClient) List(_ context.Context) ([]types.Container, error) {
+	if c.listErr != nil {
+		return nil, c.listErr
+	}
+	var result []types.Container
+	for _, id := range c.containers {
+		result = append(result, fakeContainer{id: id})
+	}
+	return result, nil
+}
+
+func (c *fakeRuntimeClient) Info(_ context.Context, id string) (types.Container, error) {
+	if !slices.Contains(c.containers, id) {
+		return nil, fmt.Errorf("container %s not found", id)
+	}
+	return fakeContainer{id: id}, nil
+}
+
+func (c *fakeRuntimeClient) Pause(_ context.Context, id string) error {
+	c.paused = append(c.paused, id)
+	return nil
+}
+
+// Watch forwards the events until the events channel or the context is closed, like the runtime clients do
+func (c *fakeRuntimeClient) Watch(ctx context.Context) (<-chan types.Event, error) {
+	result := make(chan types.Event)
+	go func() {
+		defer close(result)
+		for {
+			select {
+			case event, ok := <-c.events:
+				if !ok {
+					return
+				}
+				select {
+				case result <- event:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return result, nil
+}
+
+func Test_compositeClient_list(t *testing.T) {
+	docker := &fakeRuntimeClient{runtime: types.RuntimeDocker, containers: []string{"a"}}
+	containerd := &fakeRuntimeClient{runtime: types.RuntimeContainerd, containers: []string{"b"}}
+	client := newCompositeClient(docker, containerd)
+
+	containers, err := client.List(t.Context())
+	require.NoError(t, err)
+	require.Len(t, containers, 2)
+	assert.Equal(t, types.RuntimeDocker, containers[0].(types.RuntimeContainer).Runtime())
+	assert.Equal(t, types.RuntimeContainerd, containers[1].(types.RuntimeContainer).Runtime())
+	assert.Equal(t, "1.0", containers[1].(types.RuntimeContainer).RuntimeVersion())
+
+	containerd.listErr = errors.New("unavailable")
+	containers, err = client.List(t.Context())
+	require.NoError(t, err)
+	assert.Len(t, containers, 1)
+
+	docker.listErr = errors.New("unavailable")
+	_, err = client.List(t.Context())
+	assert.Error(t, err)
+}
+
+func Test_compositeClient_listOverlap(t *testing.T) {
+	// docker containers are listed by containerd in the moby namespace as well
+	docker := &fakeRuntimeClient{runtime: types.RuntimeDocker, containers: []string{"a"}}
+	containerd := &fakeRuntimeClient{runtime: types.RuntimeContainerd, containers: []string{"a", "b"}}
+	client := newCompositeClient(docker, containerd)
+
+	containers, err := client.List(t.Context())
+	require.NoError(t, err)
+	require.Len(t, containers, 2)
+	assert.Equal(t, "a", containers[0].Id())
+	assert.Equal(t, types.RuntimeDocker, containers[0].(types.RuntimeContainer).Runtime())
+	assert.Equal(t, "b", containers[1].Id())
+	assert.Equal(t, types.RuntimeContainerd, containers[1].(types.RuntimeContainer).Runtime())
+
+	require.NoError(t, client.Pause(t.Context(), "a"))
+	assert.Equal(t, []string{"a"}, docker.paused)
+	assert.Empty(t, containerd.paused)
+}
+
+func Test_compositeClient_route(t *testing.T) {
+	docker := &fakeRuntimeClient{runtime: types.RuntimeDocker, containers: []string{"a"}}
+	containerd := &fakeRuntimeClient{runtime: types.RuntimeContainerd, containers: []string{"b", "c"}}
+	client := newCompositeClient(docker, containerd)
+
+	require.NoError(t, client.Pause(t.Context(), "containerd://b"))
+	require.NoError(t, client.Pause(t.Context(), "a"))
+	require.NoError(t, client.Pause(t.Context(), "c"))
+	assert.Equal(t, []string{"a"}, docker.paused)
+	assert.Equal(t, []string{"b", "c"}, containerd.paused)
+
+	assert.ErrorContains(t, client.Pause(t.Context(), "cri-o://a"), "cri-o is not configured")
+	assert.ErrorContains(t, client.Pause(t.Context(), "unknown"), "not found")
+
+	info, err := client.Info(t.Context(), "c")
+	require.NoError(t, err)
+	assert.Equal(t, types.RuntimeContainerd, info.(types.RuntimeContainer).Runtime())
+}
+
+func Test_compositeClient_watch(t *testing.T) {
+	docker := &fakeRuntimeClient{runtime: types.RuntimeDocker, events: make(chan types.Event)}
+	containerd := &fakeRuntimeClient{runtime: types.RuntimeContainerd, events: make(chan types.Event)}
+	client := newCompositeClient(docker, containerd)
+
+	events, err := client.Watch(t.Context())
+	require.NoError(t, err)
+
+	containerd.events <- types.Event{Type: types.EventTypeStart, ContainerId: "new"}
+	assert.Equal(t, types.Event{Type: types.EventTypeStart, ContainerId: "new"}, <-events)
+
+	// the started container is routed to its runtime without listing
+	require.NoError(t, client.Pause(t.Context(), "new"))
+	assert.Equal(t, []string{"new"}, containerd.paused)
+
+	close(docker.events)
+	select {
+	case _, ok := <-events:
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		assert.Fail(t, "merged events not closed")
+	}
+}
@@ -11,9 +11,11 @@ import (
 	"github.com/steadybit/extension-container/extcontainer/container/containerd"
 	"github.com/steadybit/extension-container/extcontainer/container/crio"
 	"github.com/steadybit/extension-container/extcontainer/container/docker"
+	"github.com/steadybit/extension-container/extcontainer/container/podman"
 	"github.com/steadybit/extension-container/extcontainer/container/types"
 	"github.com/steadybit/extension-kit/exthealth"
 	"os"
+	"slices"
 	"time"
 )
 
@@ -27,6 +29,10 @@ func AutoDetect() (runtime types.Runtime) {
 }
 
 func NewClient() (types.Client, error) {
+	if len(config.Config.ContainerRuntimes) > 0 {
+		return newMultiRuntimeClient(config.Config.ContainerRuntimes)
+	}
+
 	runtime := types.Runtime(config.Config.ContainerRuntime)
 	socket := config.Config.ContainerSocket
 
@@ -42,29 +48,78 @@ func NewClient() (types.Client, error) {
 		socket = runtime.DefaultSocket()
 	}
 
+	client, err := newClient(runtime, socket)
+	if err != nil {
+		return nil, err
+	}
+	return instrument(client), nil
+}
+
+// newMultiRuntimeClient creates a client for each of the runtimes, in the order of types.AllRuntimes
+func newMultiRuntimeClient(sockets map[string]string) (types.Client, error) {
+	for runtime := range sockets {
+		if !slices.Contains(types.AllRuntimes, types.Runtime(runtime)) {
+			return nil, fmt.Errorf("unsupported container runtime: %s", runtime)
+		}
+	}
+
+	// the containers of Docker are listed by containerd as well, they are only discovered once using the Docker client
+	var excludedNamespaces []string
+	if _, ok := sockets[string(types.RuntimeDocker)]; ok {
+		excludedNamespaces = append(excludedNamespaces, containerd.DockerNamespace)
+	}
+
+	var clients []types.Client
+	for _, runtime := range types.AllRuntimes {
+		socket, ok := sockets[string(runtime)]
+		if !ok {
+			continue
+		}
+		if socket == "" {
+			socket = runtime.DefaultSocket()
+		}
+
+		client, err := newClient(runtime, socket, excludedNamespaces...)
+		if err != nil {
+			for _, c := range clients {
+				_ = c.Close()
+			}
+			return nil, fmt.Errorf("failed to create %s client: %w", runtime, err)
+		}
+		clients = append(clients, instrument(client))
+	}
+
+	if len(clients) == 1 {
+		return clients[0], nil
+	}
+	return newCompositeClient(clients...), nil
+}
+
+func newClient(runtime types.Runtime, socket string, excludedNamespaces ...string) (types.Client, error) {
 	switch runtime {
 	case types.RuntimeDocker:
 		return docker.New(socket)
 	case types.RuntimeContainerd:
-		return containerd.New(socket, config.Config.ContainerdNamespace)
+		return containerd.New(socket, config.Config.ContainerdNamespace, excludedNamespaces...)
 	case types.RuntimeCrio:
 		return crio.New(socket)
+	case types.RuntimePodman:
+		return podman.New(socket)
 	default:
 		return nil, fmt.Errorf("unsupported container runtime: %s", runtime)
 	}
 }
 
 func RegisterLivenessCheck(client types.Client) chan struct{} {
-	if config.Config.LivenessCheckInterval == "" || config.Config.LivenessCheckInterval == "0" {
-		log.Info().Msg("Liveness check is disabled.")
-		return nil
-	}
-
-	duration, err := time.ParseDuration(config.Config.LivenessCheckInterval)
+	duration, err := config.Config.LivenessCheckDuration()
 	if err != nil {
 		duration = 30 * time.Second
 		log.Error().Err(err).Msgf("Failed to parse liveness check interval, using default: %s", duration)
 	}
+	if duration == 0 {
+		log.Info().Msg("Liveness check is disabled.")
+		return nil
+	}
 	ticker := time.NewTicker(duration)
 	quit := make(chan struct{})
 	go func() {
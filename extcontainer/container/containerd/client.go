@@ -9,14 +9,20 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/containerd/containerd"
+	eventsapi "github.com/containerd/containerd/api/events"
 	containersapi "github.com/containerd/containerd/api/services/containers/v1"
 	tasksapi "github.com/containerd/containerd/api/services/tasks/v1"
+	"github.com/containerd/containerd/cio"
+	"github.com/containerd/containerd/events"
+	"github.com/containerd/containerd/namespaces"
 	"github.com/containerd/errdefs"
 	"github.com/containerd/errdefs/pkg/errgrpc"
+	"github.com/containerd/typeurl/v2"
 	"github.com/rs/zerolog/log"
 	"github.com/steadybit/extension-container/extcontainer/container/types"
 	"google.golang.org/grpc/codes"
@@ -25,18 +31,28 @@ import (
 
 type client struct {
 	containerd *containerd.Client
+	filter     namespaceFilter
+
+	mu     sync.RWMutex
+	owners map[string]string
 }
 
 func (c *client) Socket() string {
 	return c.containerd.Conn().Target()
 }
 
-func New(socket string, namespace string) (types.Client, error) {
-	containerdClient, err := containerd.New(socket, containerd.WithDefaultNamespace(namespace))
+// New creates a client for the containerd namespaces matching the given comma separated list of names or globs. The
+// excluded namespaces are skipped even if they match.
+func New(socket string, namespace string, excluded ...string) (types.Client, error) {
+	filter, err := parseNamespaceFilter(namespace, excluded...)
+	if err != nil {
+		return nil, err
+	}
+	containerdClient, err := containerd.New(socket, containerd.WithDefaultNamespace(filter.defaultNamespace()))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create containerd client: %w", err)
 	}
-	return &client{containerdClient}, nil
+	return &client{containerd: containerdClient, filter: filter, owners: map[string]string{}}, nil
 }
 
 func (c *client) Runtime() types.Runtime {
@@ -46,6 +62,31 @@ func (c *client) Runtime() types.Runtime {
 var errStreamNotAvailable = errors.New("streaming api not available")
 
 func (c *client) List(ctx context.Context) ([]types.Container, error) {
+	candidates, err := c.namespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []types.Container
+	owners := map[string]string{}
+	for _, namespace := range candidates {
+		containers, err := c.listNamespace(namespaces.WithNamespace(ctx, namespace))
+		if err != nil {
+			return nil, fmt.Errorf("namespace %s: %w", namespace, err)
+		}
+		for _, container := range containers {
+			owners[container.Id()] = namespace
+		}
+		result = append(result, containers...)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.owners = owners
+	return result, nil
+}
+
+func (c *client) listNamespace(ctx context.Context) ([]types.Container, error) {
 	containers := containersapi.NewContainersClient(c.containerd.Conn())
 	session, err := containers.ListStream(ctx, &containersapi.ListContainersRequest{})
 	if err != nil {
@@ -53,6 +94,7 @@ func (c *client) List(ctx context.Context) ([]types.Container, error) {
 	}
 
 	tasks := tasksapi.NewTasksClient(c.containerd.Conn())
+	metadata := c.newMetadataLookup()
 	var result []types.Container
 
 	for {
@@ -73,29 +115,36 @@ func (c *client) List(ctx context.Context) ([]types.Container, error) {
 		case <-ctx.Done():
 			return result, ctx.Err()
 		default:
-			if isContainerAlive(ctx, tasks, r.Container.ID) {
-				result = append(result, newContainer(r.Container))
+			if status, alive := containerStatus(ctx, tasks, r.Container.ID); alive {
+				result = append(result, metadata.lookup(ctx, r.Container, status))
 			}
 		}
 	}
 }
 
 func (c *client) Info(ctx context.Context, id string) (types.Container, error) {
+	ctx, err := c.withContainerNamespace(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get container %s: %w", id, err)
+	}
+
 	containers := containersapi.NewContainersClient(c.containerd.Conn())
 	r, err := containers.Get(ctx, &containersapi.GetContainerRequest{ID: id})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get container %s: %w", id, errgrpc.ToNative(err))
 	}
-	return newContainer(r.Container), nil
+	status, _ := containerStatus(ctx, tasksapi.NewTasksClient(c.containerd.Conn()), id)
+	return c.newMetadataLookup().lookup(ctx, r.Container, status), nil
 }
 
-func isContainerAlive(ctx context.Context, tasks tasksapi.TasksClient, id string) bool {
+// containerStatus returns the status of the container's task and whether the container is alive
+func containerStatus(ctx context.Context, tasks tasksapi.TasksClient, id string) (containerd.ProcessStatus, bool) {
 	status, err := getStatus(ctx, tasks, id)
 	if err != nil && !errdefs.IsNotFound(err) {
 		log.Warn().Err(err).Msg("Failed to get status for container")
-		return false
+		return status, false
 	}
-	return status == containerd.Running || status == containerd.Paused || status == containerd.Pausing
+	return status, status == containerd.Running || status == containerd.Paused || status == containerd.Pausing
 }
 
 func getStatus(ctx context.Context, tasks tasksapi.TasksClient, id string) (containerd.ProcessStatus, error) {
@@ -115,6 +164,10 @@ func getStatus(ctx context.Context, tasks tasksapi.TasksClient, id string) (cont
 }
 
 func (c *client) GetPid(ctx context.Context, containerId string) (int, error) {
+	ctx, err := c.withContainerNamespace(ctx, containerId)
+	if err != nil {
+		return 0, err
+	}
 	container, err := c.containerd.LoadContainer(ctx, containerId)
 	if err != nil {
 		return 0, fmt.Errorf("failed to load container: %w", err)
@@ -127,6 +180,10 @@ func (c *client) GetPid(ctx context.Context, containerId string) (int, error) {
 }
 
 func (c *client) Pause(ctx context.Context, id string) error {
+	ctx, err := c.withContainerNamespace(ctx, id)
+	if err != nil {
+		return err
+	}
 	container, err := c.containerd.LoadContainer(ctx, id)
 	if err != nil {
 		return fmt.Errorf("failed to load container %s: %w", id, err)
@@ -143,6 +200,10 @@ func (c *client) Pause(ctx context.Context, id string) error {
 }
 
 func (c *client) Unpause(ctx context.Context, id string) error {
+	ctx, err := c.withContainerNamespace(ctx, id)
+	if err != nil {
+		return err
+	}
 	container, err := c.containerd.LoadContainer(ctx, id)
 	if err != nil {
 		return fmt.Errorf("failed to load container %s: %w", id, err)
@@ -158,50 +219,177 @@ func (c *client) Unpause(ctx context.Context, id string) error {
 	return task.Resume(ctx)
 }
 
-func (c *client) Stop(ctx context.Context, id string, graceful bool) error {
+func (c *client) Stop(ctx context.Context, id string, graceful bool, gracePeriod time.Duration) (bool, error) {
+	ctx, err := c.withContainerNamespace(ctx, id)
+	if err != nil {
+		return false, err
+	}
 	container, err := c.containerd.LoadContainer(ctx, id)
 	if err != nil {
-		return fmt.Errorf("failed to load container %s: %w", id, err)
+		return false, fmt.Errorf("failed to load container %s: %w", id, err)
 	}
 
 	task, err := container.Task(ctx, nil)
 	if err != nil {
 		if strings.Contains(err.Error(), "no running task found") {
-			return fmt.Errorf("couldn't stop container as container %s wasn't running: %w", id, err)
+			return false, fmt.Errorf("couldn't stop container as container %s wasn't running: %w", id, err)
 		}
-		return fmt.Errorf("failed to load task for container %s: %w", id, err)
+		return false, fmt.Errorf("failed to load task for container %s: %w", id, err)
 	}
 
 	if graceful {
 		log.Info().Msgf("Sending SIGTERM to container %s", id)
 		err = task.Kill(ctx, syscall.SIGTERM)
 		if err != nil {
-			return fmt.Errorf("failed to stop container %s: %w", id, err)
+			return false, fmt.Errorf("failed to stop container %s: %w", id, err)
 		}
 
 		waitChannel, err := task.Wait(ctx)
 		if err != nil {
-			return fmt.Errorf("failed to wait for container stop %s: %w", id, err)
+			return false, fmt.Errorf("failed to wait for container stop %s: %w", id, err)
 		}
 
 		select {
 		case exitStatus := <-waitChannel:
 			if exitStatus.Error() != nil {
-				return fmt.Errorf("failed to stop container %s during grace period : %w", id, exitStatus.Error())
+				return false, fmt.Errorf("failed to stop container %s during grace period : %w", id, exitStatus.Error())
 			}
 			log.Info().Str("containerId", id).Msgf("container stopped gracefully.")
-			return nil
-		case <-time.After(10 * time.Second):
-			log.Info().Str("containerId", id).Msgf("container did not stop gracefully.")
+			return false, nil
+		case <-time.After(gracePeriod):
+			log.Info().Str("containerId", id).Msgf("container did not stop gracefully within %s.", gracePeriod)
 		}
 	}
 
 	log.Info().Str("containerId", id).Msgf("Sending SIGKILL to container")
 	err = task.Kill(ctx, syscall.SIGKILL)
 	if err != nil {
-		return fmt.Errorf("failed to kill container %s: %w", id, err)
+		return false, fmt.Errorf("failed to kill container %s: %w", id, err)
 	}
 
+	return true, nil
+}
+
+// Restart kills the task of the container and starts a new one using the stdio of the killed task. Containers of
+// Kubernetes pods are only stopped, the kubelet restarts them through the CRI plugin, which owns their logs.
+func (c *client) Restart(ctx context.Context, id string, graceful bool, timeout time.Duration) error {
+	ctx, err := c.withContainerNamespace(ctx, id)
+	if err != nil {
+		return err
+	}
+	container, err := c.containerd.LoadContainer(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to load container %s: %w", id, err)
+	}
+
+	labels, err := container.Labels(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load labels of container %s: %w", id, err)
+	}
+	if isKubernetesContainer(labels) {
+		log.Info().Str("containerId", id).Msg("Stopping Kubernetes container, the kubelet restarts it.")
+		if _, err := c.Stop(ctx, id, graceful, timeout); err != nil {
+			return fmt.Errorf("failed to stop container %s for restart: %w", id, err)
+		}
+		return nil
+	}
+
+	task, err := container.Task(ctx, nil)
+	if err != nil {
+		if strings.Contains(err.Error(), "no running task found") {
+			return fmt.Errorf("couldn't restart container as container %s wasn't running: %w", id, err)
+		}
+		return fmt.Errorf("failed to load task for container %s: %w", id, err)
+	}
+
+	stdio, err := c.taskIO(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get stdio of container %s: %w", id, err)
+	}
+
+	// wait before killing, otherwise the exit might be missed
+	exitChannel, err := task.Wait(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to wait for container stop %s: %w", id, err)
+	}
+
+	exited := false
+	if graceful {
+		log.Info().Msgf("Sending SIGTERM to container %s", id)
+		if err := task.Kill(ctx, syscall.SIGTERM); err != nil {
+			return fmt.Errorf("failed to stop container %s: %w", id, err)
+		}
+
+		select {
+		case <-exitChannel:
+			exited = true
+		case <-time.After(timeout):
+			log.Info().Str("containerId", id).Msgf("container did not stop gracefully.")
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if !exited {
+		log.Info().Str("containerId", id).Msgf("Sending SIGKILL to container")
+		if err := task.Kill(ctx, syscall.SIGKILL); err != nil {
+			return fmt.Errorf("failed to kill container %s: %w", id, err)
+		}
+		select {
+		case <-exitChannel:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if _, err := task.Delete(ctx); err != nil {
+		return fmt.Errorf("failed to delete task of container %s: %w", id, err)
+	}
+
+	newTask, err := container.NewTask(ctx, func(string) (cio.IO, error) { return &reusedIO{config: stdio}, nil })
+	if err != nil {
+		return fmt.Errorf("failed to create task for container %s: %w", id, err)
+	}
+	if err := newTask.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start container %s: %w", id, err)
+	}
+	return nil
+}
+
+// isKubernetesContainer returns whether the container belongs to a pod managed by the kubelet
+func isKubernetesContainer(labels map[string]string) bool {
+	_, ok := labels["io.kubernetes.pod.uid"]
+	return ok
+}
+
+// taskIO returns the stdio the task of the container was created with
+func (c *client) taskIO(ctx context.Context, id string) (cio.Config, error) {
+	r, err := tasksapi.NewTasksClient(c.containerd.Conn()).Get(ctx, &tasksapi.GetRequest{ContainerID: id})
+	if err != nil {
+		return cio.Config{}, errgrpc.ToNative(err)
+	}
+	return cio.Config{
+		Stdin:    r.Process.Stdin,
+		Stdout:   r.Process.Stdout,
+		Stderr:   r.Process.Stderr,
+		Terminal: r.Process.Terminal,
+	}, nil
+}
+
+// reusedIO hands the stdio of a killed task to its successor, the fifos or logging binary keep their reader
+type reusedIO struct {
+	config cio.Config
+}
+
+func (i *reusedIO) Config() cio.Config {
+	return i.config
+}
+
+func (i *reusedIO) Cancel() {}
+
+func (i *reusedIO) Wait() {}
+
+func (i *reusedIO) Close() error {
 	return nil
 }
 
@@ -213,6 +401,81 @@ func (c *client) Version(ctx context.Context) (string, error) {
 	return version.Version, nil
 }
 
+func (c *client) Watch(ctx context.Context) (<-chan types.Event, error) {
+	// the namespaces are matched for each event, as the filters can't match globs
+	topics := []string{"/containers/create", "/tasks/start", "/tasks/exit", "/tasks/paused", "/tasks/resumed", "/containers/delete"}
+	filters := make([]string, 0, len(topics))
+	for _, topic := range topics {
+		filters = append(filters, fmt.Sprintf(`topic==%q`, topic))
+	}
+
+	envelopes, errs := c.containerd.Subscribe(ctx, filters...)
+	result := make(chan types.Event)
+	go func() {
+		defer close(result)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case err := <-errs:
+				if err != nil && ctx.Err() == nil {
+					log.Debug().Err(err).Msg("containerd event stream closed")
+				}
+				return
+			case envelope := <-envelopes:
+				if envelope == nil || !c.filter.matches(envelope.Namespace) {
+					continue
+				}
+				event, ok := toEvent(envelope)
+				if !ok {
+					continue
+				}
+				if event.Type == types.EventTypeCreate {
+					c.setOwner(event.ContainerId, envelope.Namespace)
+				}
+				select {
+				case result <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return result, nil
+}
+
+func toEvent(envelope *events.Envelope) (types.Event, bool) {
+	if envelope == nil || envelope.Event == nil {
+		return types.Event{}, false
+	}
+
+	payload, err := typeurl.UnmarshalAny(envelope.Event)
+	if err != nil {
+		log.Debug().Err(err).Str("topic", envelope.Topic).Msg("failed to decode containerd event")
+		return types.Event{}, false
+	}
+
+	switch e := payload.(type) {
+	case *eventsapi.ContainerCreate:
+		return types.Event{Type: types.EventTypeCreate, ContainerId: e.ID}, true
+	case *eventsapi.TaskStart:
+		return types.Event{Type: types.EventTypeStart, ContainerId: e.ContainerID}, true
+	case *eventsapi.TaskExit:
+		// exits of exec'd processes share the topic, only the init process ends the container
+		if e.ID != e.ContainerID {
+			return types.Event{}, false
+		}
+		return types.Event{Type: types.EventTypeDie, ContainerId: e.ContainerID}, true
+	case *eventsapi.TaskPaused:
+		return types.Event{Type: types.EventTypePause, ContainerId: e.ContainerID}, true
+	case *eventsapi.TaskResumed:
+		return types.Event{Type: types.EventTypeUnpause, ContainerId: e.ContainerID}, true
+	case *eventsapi.ContainerDelete:
+		return types.Event{Type: types.EventTypeDestroy, ContainerId: e.ID}, true
+	}
+	return types.Event{}, false
+}
+
 func (c *client) Close() error {
 	return c.containerd.Close()
 }
@@ -0,0 +1,169 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package containerd
+
+import (
+	"context"
+	"net"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/containerd/containerd"
+	containersapi "github.com/containerd/containerd/api/services/containers/v1"
+	tasksapi "github.com/containerd/containerd/api/services/tasks/v1"
+	"github.com/containerd/containerd/api/types/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func Test_Restart(t *testing.T) {
+	tests := []struct {
+		name        string
+		labels      map[string]string
+		wantSignals []uint32
+		wantCreate  *tasksapi.CreateTaskRequest
+	}{
+		{
+			name:        "kubernetes container is only stopped",
+			labels:      map[string]string{"io.kubernetes.pod.uid": "b0a2b6b4", "io.kubernetes.container.name": "web"},
+			wantSignals: []uint32{uint32(syscall.SIGTERM)},
+		},
+		{
+			name:        "other container gets a new task with the original stdio",
+			labels:      map[string]string{"app": "web"},
+			wantSignals: []uint32{uint32(syscall.SIGTERM)},
+			wantCreate: &tasksapi.CreateTaskRequest{
+				ContainerID: "c1",
+				Stdout:      "binary:///usr/local/bin/nerdctl?_NERDCTL_INTERNAL_LOGGING=/var/lib/nerdctl",
+				Stderr:      "binary:///usr/local/bin/nerdctl?_NERDCTL_INTERNAL_LOGGING=/var/lib/nerdctl",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks := &fakeTasks{exited: make(chan struct{}), stdout: tt.wantCreate.GetStdout()}
+			c := newFakeClient(t, &fakeContainers{labels: tt.labels}, tasks)
+
+			require.NoError(t, c.Restart(context.Background(), "c1", true, time.Second))
+
+			tasks.mu.Lock()
+			defer tasks.mu.Unlock()
+			assert.Equal(t, tt.wantSignals, tasks.signals)
+			if tt.wantCreate == nil {
+				assert.False(t, tasks.deleted)
+				assert.Nil(t, tasks.created)
+				assert.False(t, tasks.started)
+			} else {
+				assert.True(t, tasks.deleted)
+				require.NotNil(t, tasks.created)
+				assert.Equal(t, tt.wantCreate.ContainerID, tasks.created.ContainerID)
+				assert.Equal(t, tt.wantCreate.Stdout, tasks.created.Stdout)
+				assert.Equal(t, tt.wantCreate.Stderr, tasks.created.Stderr)
+				assert.True(t, tasks.started)
+			}
+		})
+	}
+}
+
+func newFakeClient(t *testing.T, containers containersapi.ContainersServer, tasks tasksapi.TasksServer) *client {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	containersapi.RegisterContainersServer(server, containers)
+	tasksapi.RegisterTasksServer(server, tasks)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	containerdClient, err := containerd.NewWithConn(conn)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = containerdClient.Close() })
+
+	return &client{containerd: containerdClient, owners: map[string]string{"c1": "k8s.io"}}
+}
+
+type fakeContainers struct {
+	containersapi.UnimplementedContainersServer
+	labels map[string]string
+}
+
+func (f *fakeContainers) Get(_ context.Context, r *containersapi.GetContainerRequest) (*containersapi.GetContainerResponse, error) {
+	return &containersapi.GetContainerResponse{Container: &containersapi.Container{
+		ID:      r.ID,
+		Labels:  f.labels,
+		Runtime: &containersapi.Container_Runtime{Name: "io.containerd.runc.v2"},
+	}}, nil
+}
+
+// fakeTasks is a task of container c1, which exits on the first signal
+type fakeTasks struct {
+	tasksapi.UnimplementedTasksServer
+	stdout string
+	exited chan struct{}
+
+	mu      sync.Mutex
+	signals []uint32
+	deleted bool
+	created *tasksapi.CreateTaskRequest
+	started bool
+}
+
+func (f *fakeTasks) Get(_ context.Context, r *tasksapi.GetRequest) (*tasksapi.GetResponse, error) {
+	status := task.Status_RUNNING
+	select {
+	case <-f.exited:
+		status = task.Status_STOPPED
+	default:
+	}
+	return &tasksapi.GetResponse{Process: &task.Process{ID: r.ContainerID, Pid: 42, Status: status, Stdout: f.stdout, Stderr: f.stdout}}, nil
+}
+
+func (f *fakeTasks) Wait(ctx context.Context, _ *tasksapi.WaitRequest) (*tasksapi.WaitResponse, error) {
+	select {
+	case <-f.exited:
+		return &tasksapi.WaitResponse{ExitStatus: 143}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (f *fakeTasks) Kill(_ context.Context, r *tasksapi.KillRequest) (*emptypb.Empty, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.signals) == 0 {
+		close(f.exited)
+	}
+	f.signals = append(f.signals, r.Signal)
+	return &emptypb.Empty{}, nil
+}
+
+func (f *fakeTasks) Delete(_ context.Context, r *tasksapi.DeleteTaskRequest) (*tasksapi.DeleteResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deleted = true
+	return &tasksapi.DeleteResponse{ID: r.ContainerID, ExitStatus: 143}, nil
+}
+
+func (f *fakeTasks) Create(_ context.Context, r *tasksapi.CreateTaskRequest) (*tasksapi.CreateTaskResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.created = r
+	return &tasksapi.CreateTaskResponse{ContainerID: r.ContainerID, Pid: 43}, nil
+}
+
+func (f *fakeTasks) Start(_ context.Context, r *tasksapi.StartRequest) (*tasksapi.StartResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.started = true
+	return &tasksapi.StartResponse{Pid: 43}, nil
+}
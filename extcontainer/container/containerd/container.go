@@ -5,6 +5,7 @@ package containerd
 
 import (
 	containersapi "github.com/containerd/containerd/api/services/containers/v1"
+	"github.com/steadybit/extension-container/extcontainer/container/types"
 )
 
 // Container implements the engines.Container interface for containerd
@@ -12,6 +13,10 @@ type container struct {
 	id        string
 	imageName string
 	labels    map[string]string
+	networks  []types.Network
+	ports     []types.Port
+	lifecycle types.Lifecycle
+	namespace string
 }
 
 func newContainer(c *containersapi.Container) *container {
@@ -37,3 +42,19 @@ func (c *container) ImageName() string {
 func (c *container) Labels() map[string]string {
 	return c.labels
 }
+
+func (c *container) Networks() []types.Network {
+	return c.networks
+}
+
+func (c *container) Ports() []types.Port {
+	return c.ports
+}
+
+func (c *container) Lifecycle() types.Lifecycle {
+	return c.lifecycle
+}
+
+func (c *container) Namespace() string {
+	return c.namespace
+}
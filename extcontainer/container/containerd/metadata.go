@@ -0,0 +1,157 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package containerd
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/containerd/containerd"
+	containersapi "github.com/containerd/containerd/api/services/containers/v1"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/extension-container/extcontainer/container/cri"
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+	criapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+)
+
+const (
+	labelCriKind      = "io.cri-containerd.kind"
+	labelNerdctlNets  = "nerdctl/networks"
+	labelNerdctlPorts = "nerdctl/ports"
+	labelRestartCount = "containerd.io/restart.count"
+	criKindContainer  = "container"
+)
+
+// metadataLookup resolves the networks, ports and lifecycle of containerd containers. Containers created by the CRI
+// plugin are looked up using the CRI api, containers created by nerdctl using the labels nerdctl sets. For other
+// containers neither networks nor ports are known.
+// The CRI containers are listed once and cached, so a lookup should only be used for a single listing of containers.
+type metadataLookup struct {
+	cri           criapi.RuntimeServiceClient
+	criResolver   *cri.NetworkResolver
+	criListed     bool
+	criContainers map[string]*criapi.Container
+}
+
+func (c *client) newMetadataLookup() *metadataLookup {
+	runtimeService := criapi.NewRuntimeServiceClient(c.containerd.Conn())
+	return &metadataLookup{
+		cri:         runtimeService,
+		criResolver: cri.NewNetworkResolver(runtimeService),
+	}
+}
+
+func (l *metadataLookup) lookup(ctx context.Context, c *containersapi.Container, status containerd.ProcessStatus) *container {
+	result := newContainer(c)
+	result.namespace, _ = namespaces.Namespace(ctx)
+	result.lifecycle = types.Lifecycle{State: toState(status)}
+	if c.CreatedAt != nil {
+		result.lifecycle.CreatedAt = c.CreatedAt.AsTime()
+	}
+	if count, err := strconv.Atoi(c.Labels[labelRestartCount]); err == nil {
+		result.lifecycle.RestartCount = count
+	}
+
+	if c.Labels[labelCriKind] != criKindContainer {
+		result.networks = nerdctlNetworks(c.Labels)
+		result.ports = nerdctlPorts(c.Labels)
+		return result
+	}
+
+	criContainer := l.criContainer(ctx, c.ID)
+	if criContainer == nil {
+		return result
+	}
+	result.networks = l.criResolver.PodNetworks(ctx, criContainer.PodSandboxId)
+	result.ports = cri.ContainerPorts(criContainer.Annotations)
+
+	// the listed containers lack the start time, which is only part of the status
+	if r, err := l.cri.ContainerStatus(ctx, &criapi.ContainerStatusRequest{ContainerId: c.ID}); err == nil {
+		s := r.GetStatus()
+		lifecycle := cri.ContainerLifecycle(s.GetState(), s.GetCreatedAt(), s.GetStartedAt(), s.GetAnnotations())
+		// a paused task is still running for CRI
+		lifecycle.State = result.lifecycle.State
+		result.lifecycle = lifecycle
+	} else {
+		log.Debug().Err(err).Str("containerId", c.ID).Msg("failed to get CRI container status")
+	}
+	return result
+}
+
+func toState(status containerd.ProcessStatus) string {
+	switch status {
+	case containerd.Running:
+		return types.StateRunning
+	case containerd.Paused, containerd.Pausing:
+		return types.StatePaused
+	default:
+		return string(status)
+	}
+}
+
+func (l *metadataLookup) criContainer(ctx context.Context, id string) *criapi.Container {
+	if !l.criListed {
+		l.criListed = true
+		l.criContainers = map[string]*criapi.Container{}
+		r, err := l.cri.ListContainers(ctx, &criapi.ListContainersRequest{})
+		if err != nil {
+			log.Debug().Err(err).Msg("failed to list CRI containers")
+			return nil
+		}
+		for _, container := range r.Containers {
+			l.criContainers[container.Id] = container
+		}
+	}
+	return l.criContainers[id]
+}
+
+// nerdctlNetworks returns the networks from the nerdctl/networks label, the addresses are not recorded by nerdctl
+func nerdctlNetworks(labels map[string]string) []types.Network {
+	value, ok := labels[labelNerdctlNets]
+	if !ok {
+		return nil
+	}
+
+	var names []string
+	if err := json.Unmarshal([]byte(value), &names); err != nil {
+		log.Debug().Err(err).Msg("failed to parse nerdctl networks label")
+		return nil
+	}
+
+	networks := make([]types.Network, 0, len(names))
+	for _, name := range names {
+		networks = append(networks, types.Network{Name: name})
+	}
+	return networks
+}
+
+// nerdctlPorts returns the published ports from the nerdctl/ports label
+func nerdctlPorts(labels map[string]string) []types.Port {
+	value, ok := labels[labelNerdctlPorts]
+	if !ok {
+		return nil
+	}
+
+	var mappings []struct {
+		HostPort      uint16
+		ContainerPort uint16
+		Protocol      string
+		HostIP        string
+	}
+	if err := json.Unmarshal([]byte(value), &mappings); err != nil {
+		log.Debug().Err(err).Msg("failed to parse nerdctl ports label")
+		return nil
+	}
+
+	ports := make([]types.Port, 0, len(mappings))
+	for _, m := range mappings {
+		ports = append(ports, types.Port{Port: m.ContainerPort, Protocol: strings.ToLower(m.Protocol), HostIp: m.HostIP, HostPort: m.HostPort})
+	}
+	slices.SortFunc(ports, types.Port.Compare)
+	return ports
+}
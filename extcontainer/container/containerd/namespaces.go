@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package containerd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/errdefs"
+	"github.com/gobwas/glob"
+)
+
+// DockerNamespace is the namespace Docker creates its containers in, they are listed by the Docker client as well
+const DockerNamespace = "moby"
+
+// namespaceFilter selects the containerd namespaces to discover and attack containers in. It is configured as a
+// comma separated list of names or globs, "all" selects every namespace. The excluded namespaces are never selected.
+type namespaceFilter struct {
+	names    []string
+	patterns []glob.Glob
+	excluded []string
+}
+
+func parseNamespaceFilter(value string, excluded ...string) (namespaceFilter, error) {
+	filter := namespaceFilter{excluded: excluded}
+	for part := range strings.SplitSeq(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if part == "all" {
+			part = "*"
+		}
+		if !strings.ContainsAny(part, "*?[{") {
+			filter.names = append(filter.names, part)
+			continue
+		}
+		pattern, err := glob.Compile(part)
+		if err != nil {
+			return namespaceFilter{}, fmt.Errorf("invalid containerd namespace pattern %q: %w", part, err)
+		}
+		filter.patterns = append(filter.patterns, pattern)
+	}
+	if len(filter.names) == 0 && len(filter.patterns) == 0 {
+		return namespaceFilter{}, fmt.Errorf("no containerd namespace configured")
+	}
+	return filter, nil
+}
+
+func (f namespaceFilter) matches(namespace string) bool {
+	if slices.Contains(f.excluded, namespace) {
+		return false
+	}
+	return slices.Contains(f.names, namespace) || slices.ContainsFunc(f.patterns, func(p glob.Glob) bool { return p.Match(namespace) })
+}
+
+// defaultNamespace is the namespace the client is bound to when no namespace is given in the context
+func (f namespaceFilter) defaultNamespace() string {
+	if len(f.names) > 0 {
+		return f.names[0]
+	}
+	return namespaces.Default
+}
+
+// namespaces returns the existing namespaces matching the filter. Without patterns the configured names are returned
+// as they are, so no permission to list the namespaces is needed.
+func (c *client) namespaces(ctx context.Context) ([]string, error) {
+	if len(c.filter.patterns) == 0 {
+		return slices.DeleteFunc(slices.Clone(c.filter.names), func(namespace string) bool { return !c.filter.matches(namespace) }), nil
+	}
+
+	all, err := c.containerd.NamespaceService().List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containerd namespaces: %w", err)
+	}
+	return slices.DeleteFunc(all, func(namespace string) bool { return !c.filter.matches(namespace) }), nil
+}
+
+// withContainerNamespace returns the context for calls regarding the given container. The namespace is the one the
+// container was last seen in or else the first namespace containing a container with this id.
+func (c *client) withContainerNamespace(ctx context.Context, id string) (context.Context, error) {
+	c.mu.RLock()
+	namespace, ok := c.owners[id]
+	c.mu.RUnlock()
+	if ok {
+		return namespaces.WithNamespace(ctx, namespace), nil
+	}
+
+	candidates, err := c.namespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, namespace := range candidates {
+		namespaced := namespaces.WithNamespace(ctx, namespace)
+		if _, err := c.containerd.ContainerService().Get(namespaced, id); err == nil {
+			c.setOwner(id, namespace)
+			return namespaced, nil
+		} else if !errdefs.IsNotFound(err) {
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf("container %s not found in containerd namespaces %s: %w", id, strings.Join(candidates, ", "), errdefs.ErrNotFound)
+}
+
+func (c *client) setOwner(id, namespace string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.owners[id] = namespace
+}
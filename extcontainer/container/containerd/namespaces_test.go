@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package containerd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseNamespaceFilter(t *testing.T) {
+	tests := []struct {
+		value       string
+		excluded    []string
+		wantDefault string
+		matching    []string
+		notMatching []string
+		wantErr     string
+	}{
+		{
+			value:       "k8s.io",
+			wantDefault: "k8s.io",
+			matching:    []string{"k8s.io"},
+			notMatching: []string{"moby", "default"},
+		},
+		{
+			value:       "k8s.io, moby",
+			wantDefault: "k8s.io",
+			matching:    []string{"k8s.io", "moby"},
+			notMatching: []string{"default"},
+		},
+		{
+			value:       "team-*",
+			wantDefault: "default",
+			matching:    []string{"team-a", "team-b"},
+			notMatching: []string{"k8s.io"},
+		},
+		{
+			value:       "all",
+			wantDefault: "default",
+			matching:    []string{"k8s.io", "moby", "default"},
+		},
+		{
+			value:       "all",
+			excluded:    []string{DockerNamespace},
+			wantDefault: "default",
+			matching:    []string{"k8s.io", "default"},
+			notMatching: []string{"moby"},
+		},
+		{
+			value:       "k8s.io,moby",
+			excluded:    []string{DockerNamespace},
+			wantDefault: "k8s.io",
+			matching:    []string{"k8s.io"},
+			notMatching: []string{"moby"},
+		},
+		{value: " , ", wantErr: "no containerd namespace configured"},
+		{value: "team-[", wantErr: "invalid containerd namespace pattern"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			filter, err := parseNamespaceFilter(tt.value, tt.excluded...)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantDefault, filter.defaultNamespace())
+			for _, namespace := range tt.matching {
+				assert.True(t, filter.matches(namespace), namespace)
+			}
+			for _, namespace := range tt.notMatching {
+				assert.False(t, filter.matches(namespace), namespace)
+			}
+		})
+	}
+}
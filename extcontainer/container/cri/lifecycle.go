@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package cri
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+	criapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+)
+
+// ContainerLifecycle returns the lifecycle of a container, the timestamps are in nanoseconds and zero if unknown.
+// CRI has no health status, the probes are run by the kubelet.
+func ContainerLifecycle(state criapi.ContainerState, createdAt, startedAt int64, annotations map[string]string) types.Lifecycle {
+	lifecycle := types.Lifecycle{
+		State:     strings.ToLower(strings.TrimPrefix(state.String(), "CONTAINER_")),
+		CreatedAt: fromNanos(createdAt),
+		StartedAt: fromNanos(startedAt),
+	}
+	if count, err := strconv.Atoi(annotations["io.kubernetes.container.restartCount"]); err == nil {
+		lifecycle.RestartCount = count
+	}
+	return lifecycle
+}
+
+func fromNanos(nanos int64) time.Time {
+	if nanos <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos)
+}
@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+// Package cri looks up the networks and ports of Kubernetes containers using the CRI api, which is served by CRI-O
+// and containerd.
+package cri
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+	criapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+)
+
+// NetworkModePod is reported as network name for containers using the pod network
+const NetworkModePod = "pod"
+
+// NetworkResolver resolves the networks of pod sandboxes. The results are cached, so a resolver should only be used
+// for a single listing of containers.
+type NetworkResolver struct {
+	client    criapi.RuntimeServiceClient
+	sandboxes map[string][]types.Network
+}
+
+func NewNetworkResolver(client criapi.RuntimeServiceClient) *NetworkResolver {
+	return &NetworkResolver{client: client, sandboxes: map[string][]types.Network{}}
+}
+
+// PodNetworks returns the networks of the given pod sandbox, or nil if the status of the sandbox can't be read.
+func (r *NetworkResolver) PodNetworks(ctx context.Context, sandboxId string) []types.Network {
+	if sandboxId == "" {
+		return nil
+	}
+	if networks, ok := r.sandboxes[sandboxId]; ok {
+		return networks
+	}
+
+	networks, err := r.podNetworks(ctx, sandboxId)
+	if err != nil {
+		log.Debug().Err(err).Str("sandboxId", sandboxId).Msg("failed to get pod sandbox network status")
+	}
+	r.sandboxes[sandboxId] = networks
+	return networks
+}
+
+func (r *NetworkResolver) podNetworks(ctx context.Context, sandboxId string) ([]types.Network, error) {
+	res, err := r.client.PodSandboxStatus(ctx, &criapi.PodSandboxStatusRequest{PodSandboxId: sandboxId})
+	if err != nil {
+		return nil, err
+	}
+
+	status := res.GetStatus()
+	if status.GetLinux().GetNamespaces().GetOptions().GetNetwork() == criapi.NamespaceMode_NODE {
+		return []types.Network{{Name: types.NetworkModeHost}}, nil
+	}
+
+	network := types.Network{Name: NetworkModePod}
+	if ip := status.GetNetwork().GetIp(); ip != "" {
+		network.Ips = append(network.Ips, ip)
+	}
+	for _, additional := range status.GetNetwork().GetAdditionalIps() {
+		if ip := additional.GetIp(); ip != "" && !slices.Contains(network.Ips, ip) {
+			network.Ips = append(network.Ips, ip)
+		}
+	}
+	return []types.Network{network}, nil
+}
+
+// containerPort is the port as serialized by the kubelet into the io.kubernetes.container.ports annotation
+type containerPort struct {
+	ContainerPort uint16 `json:"containerPort"`
+	Protocol      string `json:"protocol"`
+	HostIP        string `json:"hostIP"`
+	HostPort      uint16 `json:"hostPort"`
+}
+
+// ContainerPorts returns the ports declared in the pod spec for the container, read from the annotations set by the
+// kubelet.
+func ContainerPorts(annotations map[string]string) []types.Port {
+	value, ok := annotations["io.kubernetes.container.ports"]
+	if !ok {
+		return nil
+	}
+
+	var ports []containerPort
+	if err := json.Unmarshal([]byte(value), &ports); err != nil {
+		log.Debug().Err(err).Msg("failed to parse container ports annotation")
+		return nil
+	}
+
+	result := make([]types.Port, 0, len(ports))
+	for _, p := range ports {
+		protocol := strings.ToLower(p.Protocol)
+		if protocol == "" {
+			protocol = "tcp"
+		}
+		result = append(result, types.Port{Port: p.ContainerPort, Protocol: protocol, HostIp: p.HostIP, HostPort: p.HostPort})
+	}
+	slices.SortFunc(result, types.Port.Compare)
+	return result
+}
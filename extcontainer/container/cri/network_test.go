@@ -0,0 +1,24 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package cri
+
+import (
+	"testing"
+
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ContainerPorts(t *testing.T) {
+	ports := ContainerPorts(map[string]string{
+		"io.kubernetes.container.ports": `[{"name":"https","containerPort":8443,"protocol":"TCP"},{"containerPort":53,"protocol":"UDP","hostPort":53,"hostIP":"10.0.0.1"}]`,
+	})
+	assert.Equal(t, []types.Port{
+		{Port: 53, Protocol: "udp", HostIp: "10.0.0.1", HostPort: 53},
+		{Port: 8443, Protocol: "tcp"},
+	}, ports)
+
+	assert.Nil(t, ContainerPorts(map[string]string{}))
+	assert.Nil(t, ContainerPorts(map[string]string{"io.kubernetes.container.ports": "not json"}))
+}
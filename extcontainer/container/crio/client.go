@@ -8,10 +8,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/extension-container/extcontainer/container/cri"
 	"github.com/steadybit/extension-container/extcontainer/container/types"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 	criapi "k8s.io/cri-api/pkg/apis/runtime/v1"
 	"net"
 	"time"
@@ -79,9 +83,17 @@ func (c *client) List(ctx context.Context) ([]types.Container, error) {
 		return nil, fmt.Errorf("failed to list CRI-O containers: %w", err)
 	}
 
+	networks := cri.NewNetworkResolver(c.cri)
 	result := make([]types.Container, 0, len(containerList.Containers))
 	for _, container := range containerList.Containers {
-		result = append(result, newContainer(container))
+		podNetworks := networks.PodNetworks(ctx, container.PodSandboxId)
+		// the listed containers lack the start time, which is only part of the status
+		if status, err := c.cri.ContainerStatus(ctx, &criapi.ContainerStatusRequest{ContainerId: container.Id}); err == nil {
+			result = append(result, newContainerFromStatus(status.Status, podNetworks))
+		} else {
+			log.Debug().Err(err).Str("containerId", container.Id).Msg("failed to get status of CRI-O container")
+			result = append(result, newContainer(container, podNetworks))
+		}
 	}
 	return result, nil
 }
@@ -91,7 +103,15 @@ func (c *client) Info(ctx context.Context, id string) (types.Container, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to get CRI-O container %s: %w", id, err)
 	}
-	return newContainerFromStatus(r.Status), nil
+
+	// the container status lacks the pod sandbox id, which is needed for the network status
+	var networks []types.Network
+	if l, err := c.cri.ListContainers(ctx, &criapi.ListContainersRequest{Filter: &criapi.ContainerFilter{Id: id}}); err != nil {
+		log.Debug().Err(err).Str("containerId", id).Msg("failed to get pod sandbox of CRI-O container")
+	} else if len(l.Containers) > 0 {
+		networks = cri.NewNetworkResolver(c.cri).PodNetworks(ctx, l.Containers[0].PodSandboxId)
+	}
+	return newContainerFromStatus(r.Status, networks), nil
 }
 
 func (c *client) GetPid(ctx context.Context, containerId string) (int, error) {
@@ -116,25 +136,52 @@ func (c *client) GetPid(ctx context.Context, containerId string) (int, error) {
 	return info.Pid, nil
 }
 
+// Pause is not part of the CRI, containers are paused using the cgroup freezer instead.
 func (c *client) Pause(_ context.Context, _ string) error {
 	return fmt.Errorf("not supported")
 }
 
+// Unpause is not part of the CRI, containers are unpaused using the cgroup freezer instead.
 func (c *client) Unpause(_ context.Context, _ string) error {
 	return fmt.Errorf("not supported")
 }
 
-func (c *client) Stop(ctx context.Context, id string, graceful bool) error {
-	timeout := 10
-	if !graceful {
-		timeout = 0
+func (c *client) Stop(ctx context.Context, id string, graceful bool, gracePeriod time.Duration) (bool, error) {
+	timeout := int64(0)
+	if graceful {
+		timeout = int64(types.TimeoutSeconds(gracePeriod))
 	}
 	_, err := c.cri.StopContainer(ctx, &criapi.StopContainerRequest{
 		ContainerId: id,
-		Timeout:     int64(timeout),
+		Timeout:     timeout,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to stop CRI-O container %s: %w", id, err)
+		return false, fmt.Errorf("failed to stop CRI-O container %s: %w", id, err)
+	}
+
+	r, err := c.cri.ContainerStatus(ctx, &criapi.ContainerStatusRequest{ContainerId: id})
+	if status.Code(err) == codes.NotFound {
+		// the kubelet already removed the stopped container, its exit code is gone
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to get status of stopped CRI-O container %s: %w", id, err)
+	}
+	return r.GetStatus().GetExitCode() == types.ExitCodeKilled, nil
+}
+
+// Restart only stops the container, CRI has no api to start it again. The kubelet replaces the stopped container with a
+// new one according to the restart policy of the pod.
+func (c *client) Restart(ctx context.Context, id string, graceful bool, timeout time.Duration) error {
+	var seconds int64
+	if graceful {
+		seconds = int64(types.TimeoutSeconds(timeout))
+	}
+	_, err := c.cri.StopContainer(ctx, &criapi.StopContainerRequest{
+		ContainerId: id,
+		Timeout:     seconds,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to stop CRI-O container %s for restart: %w", id, err)
 	}
 	return nil
 }
@@ -147,6 +194,49 @@ func (c *client) Version(ctx context.Context) (string, error) {
 	return versionResponse.RuntimeVersion, nil
 }
 
+func (c *client) Watch(ctx context.Context) (<-chan types.Event, error) {
+	stream, err := c.cri.GetContainerEvents(ctx, &criapi.GetEventsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch CRI-O container events: %w", err)
+	}
+
+	result := make(chan types.Event)
+	go func() {
+		defer close(result)
+		for {
+			r, err := stream.Recv()
+			if err != nil {
+				if ctx.Err() == nil {
+					log.Debug().Err(err).Msg("CRI-O event stream closed")
+				}
+				return
+			}
+
+			// CRI knows neither pausing nor health checks, their changes are picked up by the next listing
+			event := types.Event{ContainerId: r.ContainerId}
+			switch r.ContainerEventType {
+			case criapi.ContainerEventType_CONTAINER_CREATED_EVENT:
+				event.Type = types.EventTypeCreate
+			case criapi.ContainerEventType_CONTAINER_STARTED_EVENT:
+				event.Type = types.EventTypeStart
+			case criapi.ContainerEventType_CONTAINER_STOPPED_EVENT:
+				event.Type = types.EventTypeDie
+			case criapi.ContainerEventType_CONTAINER_DELETED_EVENT:
+				event.Type = types.EventTypeDestroy
+			default:
+				continue
+			}
+
+			select {
+			case result <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return result, nil
+}
+
 func (c *client) Close() error {
 	return c.connection.Close()
 }
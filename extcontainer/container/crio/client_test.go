@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package crio
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	criapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+)
+
+type fakeRuntimeService struct {
+	criapi.RuntimeServiceClient
+	stopped   *criapi.StopContainerRequest
+	exitCode  int32
+	statusErr error
+}
+
+func (f *fakeRuntimeService) StopContainer(_ context.Context, r *criapi.StopContainerRequest, _ ...grpc.CallOption) (*criapi.StopContainerResponse, error) {
+	f.stopped = r
+	return &criapi.StopContainerResponse{}, nil
+}
+
+func (f *fakeRuntimeService) ContainerStatus(_ context.Context, _ *criapi.ContainerStatusRequest, _ ...grpc.CallOption) (*criapi.ContainerStatusResponse, error) {
+	if f.statusErr != nil {
+		return nil, f.statusErr
+	}
+	return &criapi.ContainerStatusResponse{Status: &criapi.ContainerStatus{ExitCode: f.exitCode}}, nil
+}
+
+func Test_Stop(t *testing.T) {
+	tests := []struct {
+		name        string
+		fake        *fakeRuntimeService
+		gracePeriod time.Duration
+		wantTimeout int64
+		wantKilled  bool
+		wantErr     bool
+	}{
+		{name: "exited", fake: &fakeRuntimeService{exitCode: 143}, gracePeriod: 10 * time.Second, wantTimeout: 10},
+		{name: "killed", fake: &fakeRuntimeService{exitCode: types.ExitCodeKilled}, gracePeriod: 10 * time.Second, wantTimeout: 10, wantKilled: true},
+		{name: "sub-second grace period", fake: &fakeRuntimeService{}, gracePeriod: 300 * time.Millisecond, wantTimeout: 1},
+		{name: "removed by the kubelet", fake: &fakeRuntimeService{statusErr: status.Error(codes.NotFound, "could not find container")}, gracePeriod: time.Second, wantTimeout: 1},
+		{name: "status failed", fake: &fakeRuntimeService{statusErr: status.Error(codes.Unavailable, "connection refused")}, gracePeriod: time.Second, wantTimeout: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &client{cri: tt.fake}
+			killed, err := c.Stop(t.Context(), "c1", true, tt.gracePeriod)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantKilled, killed)
+			require.NotNil(t, tt.fake.stopped)
+			assert.Equal(t, tt.wantTimeout, tt.fake.stopped.Timeout)
+		})
+	}
+}
@@ -3,7 +3,11 @@
 
 package crio
 
-import runtime "k8s.io/cri-api/pkg/apis/runtime/v1"
+import (
+	"github.com/steadybit/extension-container/extcontainer/container/cri"
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+	runtime "k8s.io/cri-api/pkg/apis/runtime/v1"
+)
 
 // Container implements the types.Container interface for CRI
 type container struct {
@@ -11,23 +15,32 @@ type container struct {
 	name      string
 	imageName string
 	labels    map[string]string
+	networks  []types.Network
+	ports     []types.Port
+	lifecycle types.Lifecycle
 }
 
-func newContainer(c *runtime.Container) *container {
+func newContainer(c *runtime.Container, networks []types.Network) *container {
 	return &container{
 		id:        c.Id,
 		name:      c.Metadata.Name,
 		imageName: c.Image.Image,
 		labels:    c.Labels,
+		networks:  networks,
+		ports:     cri.ContainerPorts(c.Annotations),
+		lifecycle: cri.ContainerLifecycle(c.State, c.CreatedAt, 0, c.Annotations),
 	}
 }
 
-func newContainerFromStatus(c *runtime.ContainerStatus) *container {
+func newContainerFromStatus(c *runtime.ContainerStatus, networks []types.Network) *container {
 	return &container{
 		id:        c.Id,
 		name:      c.Metadata.Name,
 		imageName: c.Image.Image,
 		labels:    c.Labels,
+		networks:  networks,
+		ports:     cri.ContainerPorts(c.Annotations),
+		lifecycle: cri.ContainerLifecycle(c.State, c.CreatedAt, c.StartedAt, c.Annotations),
 	}
 }
 
@@ -46,3 +59,15 @@ func (c *container) ImageName() string {
 func (c *container) Labels() map[string]string {
 	return c.labels
 }
+
+func (c *container) Networks() []types.Network {
+	return c.networks
+}
+
+func (c *container) Ports() []types.Port {
+	return c.ports
+}
+
+func (c *container) Lifecycle() types.Lifecycle {
+	return c.lifecycle
+}
@@ -6,10 +6,13 @@ package docker
 import (
 	"context"
 	"fmt"
+	"github.com/moby/moby/api/types/events"
 	dclient "github.com/moby/moby/client"
+	"github.com/rs/zerolog/log"
 	"github.com/steadybit/extension-container/extcontainer"
 	"github.com/steadybit/extension-container/extcontainer/container/types"
 	"strings"
+	"time"
 )
 
 type client struct {
@@ -45,8 +48,15 @@ func (c *client) List(ctx context.Context) ([]types.Container, error) {
 	}
 
 	result := make([]types.Container, 0, len(listResult.Items))
-	for _, container := range listResult.Items {
-		result = append(result, newContainer(container))
+	for _, summary := range listResult.Items {
+		container := newContainer(summary)
+		// the list lacks the start time, restart count and pid
+		if r, err := c.docker.ContainerInspect(ctx, summary.ID, dclient.ContainerInspectOptions{}); err == nil {
+			container.lifecycle = toLifecycle(r.Container)
+		} else {
+			log.Debug().Err(err).Str("containerId", summary.ID).Msg("failed to inspect docker container")
+		}
+		result = append(result, container)
 	}
 	return result, nil
 }
@@ -77,15 +87,33 @@ func (c *client) Unpause(ctx context.Context, id string) error {
 	return err
 }
 
-func (c *client) Stop(ctx context.Context, id string, graceful bool) error {
-	opt := dclient.ContainerStopOptions{}
-	if !graceful {
-		opt.Timeout = new(0)
+func (c *client) Stop(ctx context.Context, id string, graceful bool, gracePeriod time.Duration) (bool, error) {
+	opt := dclient.ContainerStopOptions{Timeout: new(0)}
+	if graceful {
+		opt.Timeout = new(types.TimeoutSeconds(gracePeriod))
 	}
 
 	_, err := c.docker.ContainerStop(ctx, id, opt)
 	if err != nil {
-		return fmt.Errorf("failed to stop container %s: %w", id, err)
+		return false, fmt.Errorf("failed to stop container %s: %w", id, err)
+	}
+
+	info, err := c.docker.ContainerInspect(ctx, id, dclient.ContainerInspectOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to inspect stopped container %s: %w", id, err)
+	}
+	return info.Container.State.ExitCode == types.ExitCodeKilled, nil
+}
+
+func (c *client) Restart(ctx context.Context, id string, graceful bool, timeout time.Duration) error {
+	opt := dclient.ContainerRestartOptions{Timeout: new(0)}
+	if graceful {
+		opt.Timeout = new(types.TimeoutSeconds(timeout))
+	}
+
+	_, err := c.docker.ContainerRestart(ctx, id, opt)
+	if err != nil {
+		return fmt.Errorf("failed to restart container %s: %w", id, err)
 	}
 	return nil
 }
@@ -98,6 +126,53 @@ func (c *client) Version(ctx context.Context) (string, error) {
 	return version.Version, nil
 }
 
+func (c *client) Watch(ctx context.Context) (<-chan types.Event, error) {
+	eventFilters := make(dclient.Filters)
+	eventFilters.Add("type", string(events.ContainerEventType))
+	eventFilters.Add("event", string(events.ActionCreate), string(events.ActionStart), string(events.ActionDie), string(events.ActionDestroy),
+		string(events.ActionPause), string(events.ActionUnPause), string(events.ActionHealthStatus))
+
+	stream := c.docker.Events(ctx, dclient.EventsListOptions{Filters: eventFilters})
+	result := make(chan types.Event)
+	go func() {
+		defer close(result)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case err := <-stream.Err:
+				if err != nil && ctx.Err() == nil {
+					log.Debug().Err(err).Msg("docker event stream closed")
+				}
+				return
+			case msg := <-stream.Messages:
+				event, ok := toEvent(msg)
+				if !ok {
+					continue
+				}
+				select {
+				case result <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return result, nil
+}
+
+// toEvent maps the docker event. The health is part of the action of health_status events, e.g.
+// "health_status: healthy", free-form statuses are skipped.
+func toEvent(msg events.Message) (types.Event, bool) {
+	event := types.Event{Type: types.EventType(msg.Action), ContainerId: msg.Actor.ID}
+	if !strings.HasPrefix(string(msg.Action), string(events.ActionHealthStatus)) {
+		return event, true
+	}
+	event.Type = types.EventTypeHealthStatus
+	event.Health = strings.TrimPrefix(string(msg.Action), string(events.ActionHealthStatus)+": ")
+	return event, event.Health == types.HealthStarting || event.Health == types.HealthHealthy || event.Health == types.HealthUnhealthy
+}
+
 func (c *client) Close() error {
 	return c.docker.Close()
 }
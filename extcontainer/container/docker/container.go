@@ -4,7 +4,16 @@
 package docker
 
 import (
+	"maps"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
 	typecontainer "github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/network"
+	"github.com/steadybit/extension-container/extcontainer/container/types"
 )
 
 // container implements the types.Container interface for Docker
@@ -13,24 +22,152 @@ type container struct {
 	names     []string
 	imageName string
 	labels    map[string]string
+	networks  []types.Network
+	ports     []types.Port
+	lifecycle types.Lifecycle
 }
 
 func newContainer(c typecontainer.Summary) *container {
+	var endpoints map[string]*network.EndpointSettings
+	if c.NetworkSettings != nil {
+		endpoints = c.NetworkSettings.Networks
+	}
+
+	ports := make([]types.Port, 0, len(c.Ports))
+	for _, p := range c.Ports {
+		ports = append(ports, types.Port{
+			Port:     p.PrivatePort,
+			Protocol: p.Type,
+			HostIp:   addrString(p.IP),
+			HostPort: p.PublicPort,
+		})
+	}
+	slices.SortFunc(ports, types.Port.Compare)
+
 	return &container{
 		id:        c.ID,
 		names:     c.Names,
 		imageName: c.Image,
 		labels:    c.Labels,
+		networks:  toNetworks(c.HostConfig.NetworkMode, endpoints),
+		ports:     ports,
+		lifecycle: types.Lifecycle{
+			State:     string(c.State),
+			CreatedAt: time.Unix(c.Created, 0),
+			Health:    summaryHealth(c),
+		},
 	}
 }
 
+// summaryHealth returns the health of a listed container. Older daemons only report it as part of the status text,
+// e.g. "Up 5 minutes (healthy)".
+func summaryHealth(c typecontainer.Summary) string {
+	if c.Health != nil {
+		return toHealth(c.Health.Status)
+	}
+	switch {
+	case strings.HasSuffix(c.Status, "(healthy)"):
+		return types.HealthHealthy
+	case strings.HasSuffix(c.Status, "(unhealthy)"):
+		return types.HealthUnhealthy
+	case strings.HasSuffix(c.Status, "(health: starting)"):
+		return types.HealthStarting
+	}
+	return ""
+}
+
+func toHealth(status typecontainer.HealthStatus) string {
+	if status == typecontainer.NoHealthcheck {
+		return ""
+	}
+	return string(status)
+}
+
+func toLifecycle(c typecontainer.InspectResponse) types.Lifecycle {
+	lifecycle := types.Lifecycle{RestartCount: c.RestartCount}
+	lifecycle.CreatedAt, _ = time.Parse(time.RFC3339Nano, c.Created)
+	if c.State != nil {
+		lifecycle.State = string(c.State.Status)
+		lifecycle.StartedAt, _ = time.Parse(time.RFC3339Nano, c.State.StartedAt)
+		lifecycle.Pid = c.State.Pid
+		if c.State.Health != nil {
+			lifecycle.Health = toHealth(c.State.Health.Status)
+		}
+	}
+	return lifecycle
+}
+
 func newContainerFromInspect(c typecontainer.InspectResponse) *container {
-	return &container{
+	result := &container{
 		id:        c.ID,
 		names:     []string{c.Name},
 		imageName: c.Image,
 		labels:    c.Config.Labels,
+		lifecycle: toLifecycle(c),
+	}
+
+	var networkMode string
+	if c.HostConfig != nil {
+		networkMode = string(c.HostConfig.NetworkMode)
 	}
+	if c.NetworkSettings != nil {
+		result.networks = toNetworks(networkMode, c.NetworkSettings.Networks)
+		result.ports = toPorts(c.NetworkSettings.Ports)
+	} else {
+		result.networks = toNetworks(networkMode, nil)
+	}
+	return result
+}
+
+func toNetworks(networkMode string, endpoints map[string]*network.EndpointSettings) []types.Network {
+	if len(endpoints) == 0 {
+		if networkMode == "" {
+			return nil
+		}
+		return []types.Network{{Name: networkMode}}
+	}
+
+	result := make([]types.Network, 0, len(endpoints))
+	for _, name := range slices.Sorted(maps.Keys(endpoints)) {
+		n := types.Network{Name: name}
+		if endpoint := endpoints[name]; endpoint != nil {
+			for _, ip := range []netip.Addr{endpoint.IPAddress, endpoint.GlobalIPv6Address} {
+				if ip.IsValid() {
+					n.Ips = append(n.Ips, ip.String())
+				}
+			}
+		}
+		result = append(result, n)
+	}
+	return result
+}
+
+func toPorts(portMap network.PortMap) []types.Port {
+	var result []types.Port
+	for port, bindings := range portMap {
+		p := types.Port{Port: port.Num(), Protocol: string(port.Proto())}
+		if len(bindings) == 0 {
+			result = append(result, p)
+			continue
+		}
+		for _, binding := range bindings {
+			published := p
+			published.HostIp = addrString(binding.HostIP)
+			if hostPort, err := strconv.ParseUint(binding.HostPort, 10, 16); err == nil {
+				published.HostPort = uint16(hostPort)
+			}
+			result = append(result, published)
+		}
+	}
+	slices.SortFunc(result, types.Port.Compare)
+	return result
+}
+
+func addrString(addr netip.Addr) string {
+	if !addr.IsValid() {
+		return ""
+	}
+	return addr.String()
 }
 
 func (c *container) Id() string {
@@ -51,3 +188,15 @@ func (c *container) ImageName() string {
 func (c *container) Labels() map[string]string {
 	return c.labels
 }
+
+func (c *container) Networks() []types.Network {
+	return c.networks
+}
+
+func (c *container) Ports() []types.Port {
+	return c.ports
+}
+
+func (c *container) Lifecycle() types.Lifecycle {
+	return c.lifecycle
+}
@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package container
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/steadybit/extension-container/extcontainer/audit"
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+	"github.com/steadybit/extension-container/extcontainer/metrics"
+)
+
+// instrumentedClient records the latency and errors of all calls to the container runtime. The calls changing a
+// container are recorded as commands in the audit log.
+type instrumentedClient struct {
+	types.Client
+}
+
+func instrument(client types.Client) types.Client {
+	return &instrumentedClient{Client: client}
+}
+
+// command records the call changing the container in the audit log, e.g. "docker stop abc"
+func (c *instrumentedClient) command(ctx context.Context, method, id string, args ...any) {
+	command := fmt.Sprintf("%s %s %s", c.Client.Runtime(), method, id)
+	for _, arg := range args {
+		command += fmt.Sprintf(" %v", arg)
+	}
+	audit.RecordCommands(ctx, command)
+}
+
+func (c *instrumentedClient) observe(method string, start time.Time, err error) {
+	runtime := string(c.Client.Runtime())
+	metrics.ClientRequestDuration.WithLabelValues(runtime, method).Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.ClientRequestErrors.WithLabelValues(runtime, method).Inc()
+	}
+}
+
+func (c *instrumentedClient) List(ctx context.Context) (result []types.Container, err error) {
+	defer func(start time.Time) { c.observe("List", start, err) }(time.Now())
+	return c.Client.List(ctx)
+}
+
+func (c *instrumentedClient) Info(ctx context.Context, id string) (result types.Container, err error) {
+	defer func(start time.Time) { c.observe("Info", start, err) }(time.Now())
+	return c.Client.Info(ctx, id)
+}
+
+func (c *instrumentedClient) GetPid(ctx context.Context, id string) (result int, err error) {
+	defer func(start time.Time) { c.observe("GetPid", start, err) }(time.Now())
+	return c.Client.GetPid(ctx, id)
+}
+
+func (c *instrumentedClient) Pause(ctx context.Context, id string) (err error) {
+	defer func(start time.Time) { c.observe("Pause", start, err) }(time.Now())
+	c.command(ctx, "pause", id)
+	return c.Client.Pause(ctx, id)
+}
+
+func (c *instrumentedClient) Unpause(ctx context.Context, id string) (err error) {
+	defer func(start time.Time) { c.observe("Unpause", start, err) }(time.Now())
+	c.command(ctx, "unpause", id)
+	return c.Client.Unpause(ctx, id)
+}
+
+func (c *instrumentedClient) Stop(ctx context.Context, id string, graceful bool, gracePeriod time.Duration) (killed bool, err error) {
+	defer func(start time.Time) { c.observe("Stop", start, err) }(time.Now())
+	if graceful {
+		c.command(ctx, "stop", id, fmt.Sprintf("--time=%d", types.TimeoutSeconds(gracePeriod)))
+	} else {
+		c.command(ctx, "kill", id)
+	}
+	return c.Client.Stop(ctx, id, graceful, gracePeriod)
+}
+
+func (c *instrumentedClient) Restart(ctx context.Context, id string, graceful bool, timeout time.Duration) (err error) {
+	defer func(start time.Time) { c.observe("Restart", start, err) }(time.Now())
+	if graceful {
+		c.command(ctx, "restart", id, fmt.Sprintf("--time=%d", types.TimeoutSeconds(timeout)))
+	} else {
+		c.command(ctx, "restart", id, "--time=0")
+	}
+	return c.Client.Restart(ctx, id, graceful, timeout)
+}
+
+func (c *instrumentedClient) Version(ctx context.Context) (result string, err error) {
+	defer func(start time.Time) { c.observe("Version", start, err) }(time.Now())
+	return c.Client.Version(ctx)
+}
+
+// Watch only records establishing the event stream, not the stream itself.
+func (c *instrumentedClient) Watch(ctx context.Context) (result <-chan types.Event, err error) {
+	defer func(start time.Time) { c.observe("Watch", start, err) }(time.Now())
+	return c.Client.Watch(ctx)
+}
@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package container
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/steadybit/extension-container/extcontainer/audit"
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+	"github.com/steadybit/extension-container/extcontainer/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+type failingClient struct {
+	types.Client
+}
+
+func (c *failingClient) Runtime() types.Runtime {
+	return "test"
+}
+
+func (c *failingClient) List(_ context.Context) ([]types.Container, error) {
+	return nil, errors.New("unavailable")
+}
+
+func (c *failingClient) Version(_ context.Context) (string, error) {
+	return "1.0", nil
+}
+
+func (c *failingClient) Pause(_ context.Context, _ string) error {
+	return nil
+}
+
+func (c *failingClient) Stop(_ context.Context, _ string, _ bool, _ time.Duration) (bool, error) {
+	return false, nil
+}
+
+func Test_instrumentedClient(t *testing.T) {
+	client := instrument(&failingClient{})
+
+	_, err := client.List(t.Context())
+	assert.Error(t, err)
+	_, err = client.Version(t.Context())
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.ClientRequestErrors.WithLabelValues("test", "List")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(metrics.ClientRequestErrors.WithLabelValues("test", "Version")))
+	assert.Equal(t, 2, testutil.CollectAndCount(metrics.ClientRequestDuration))
+}
+
+func Test_instrumentedClient_recordsCommands(t *testing.T) {
+	client := instrument(&failingClient{})
+	ctx, commands := audit.WithCommands(t.Context())
+
+	_, _ = client.List(ctx)
+	_ = client.Pause(ctx, "abc")
+	_, _ = client.Stop(ctx, "abc", true, 1500*time.Millisecond)
+	_, _ = client.Stop(ctx, "abc", false, 0)
+
+	assert.Equal(t, []string{"test pause abc", "test stop abc --time=2", "test kill abc"}, commands())
+}
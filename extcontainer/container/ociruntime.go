@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package container
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/steadybit/action-kit/go/action_kit_commons/ociruntime"
+	"github.com/steadybit/extension-container/config"
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+)
+
+// NewOciRuntime creates the OCI runtime used to read the state of the containers and to run the sidecars. A client
+// spanning several runtimes or containerd namespaces gets an OCI runtime root for each of them.
+func NewOciRuntime(client types.Client) ociruntime.OciRuntime {
+	cfg := ociruntime.ConfigFromEnvironment()
+
+	var roots []string
+	if composite, ok := client.(*compositeClient); ok {
+		for _, runtime := range composite.Runtimes() {
+			roots = append(roots, runcRoots(runtime)...)
+		}
+	} else if cfg.Root != "" {
+		roots = []string{cfg.Root}
+	} else {
+		roots = runcRoots(client.Runtime())
+	}
+
+	result := &ociRuntimes{}
+	for _, root := range roots {
+		rootCfg := cfg
+		rootCfg.Root = root
+		if result.OciRuntime == nil {
+			result.OciRuntime = ociruntime.NewOciRuntimeWithCrunForSidecars(rootCfg)
+		} else {
+			result.others = append(result.others, ociruntime.NewOciRuntimeWithCrunForSidecars(rootCfg))
+		}
+	}
+	if len(result.others) == 0 {
+		return result.OciRuntime
+	}
+	return result
+}
+
+func runcRoots(runtime types.Runtime) []string {
+	if root := config.Config.OciRuntimeRoots[string(runtime)]; root != "" {
+		return []string{root}
+	}
+	if runtime == types.RuntimeContainerd {
+		if roots := containerdRuncRoots(filepath.Dir(types.DefaultRuncRootContainerd), config.Config.ContainerdNamespace); len(roots) > 0 {
+			return roots
+		}
+	}
+	return []string{runtime.DefaultRuncRoot()}
+}
+
+// containerdRuncRoots returns the runc root of each configured containerd namespace, as containerd keeps a root per
+// namespace. Globs are resolved against the existing roots.
+func containerdRuncRoots(base, namespaces string) []string {
+	var roots []string
+	for namespace := range strings.SplitSeq(namespaces, ",") {
+		namespace = strings.TrimSpace(namespace)
+		if namespace == "" {
+			continue
+		}
+		if namespace == "all" {
+			namespace = "*"
+		}
+		if !strings.ContainsAny(namespace, "*?[{") {
+			roots = append(roots, filepath.Join(base, namespace))
+			continue
+		}
+		matches, _ := filepath.Glob(filepath.Join(base, namespace))
+		roots = append(roots, matches...)
+	}
+	return slices.Compact(roots)
+}
+
+// ociRuntimes looks up the state of containers in the roots of all runtimes. Everything else, like running the
+// sidecars, is done using the root of the first runtime.
+type ociRuntimes struct {
+	ociruntime.OciRuntime
+	others []ociruntime.OciRuntime
+}
+
+func (r *ociRuntimes) State(ctx context.Context, id string) (*ociruntime.ContainerState, error) {
+	state, err := r.OciRuntime.State(ctx, id)
+	if err == nil {
+		return state, nil
+	}
+
+	errs := []error{err}
+	for _, other := range r.others {
+		state, err := other.State(ctx, id)
+		if err == nil {
+			return state, nil
+		}
+		errs = append(errs, err)
+	}
+	return nil, errors.Join(errs...)
+}
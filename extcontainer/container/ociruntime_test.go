@@ -0,0 +1,25 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package container
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_containerdRuncRoots(t *testing.T) {
+	base := t.TempDir()
+	for _, namespace := range []string{"k8s.io", "team-a", "team-b"} {
+		require.NoError(t, os.Mkdir(filepath.Join(base, namespace), 0755))
+	}
+
+	assert.Equal(t, []string{filepath.Join(base, "k8s.io")}, containerdRuncRoots(base, "k8s.io"))
+	assert.Equal(t, []string{filepath.Join(base, "moby"), filepath.Join(base, "team-a"), filepath.Join(base, "team-b")}, containerdRuncRoots(base, "moby, team-*"))
+	assert.Len(t, containerdRuncRoots(base, "all"), 3)
+	assert.Empty(t, containerdRuncRoots(base, "other-*"))
+}
@@ -0,0 +1,350 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package podman
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// apiPrefix is the libpod REST API base path. v4.0.0 is understood by Podman 4 and 5.
+const apiPrefix = "/v4.0.0/libpod"
+
+type client struct {
+	http    *http.Client
+	baseUrl string
+	socket  string
+}
+
+func (c *client) Socket() string {
+	return c.socket
+}
+
+func (c *client) Runtime() types.Runtime {
+	return types.RuntimePodman
+}
+
+// New creates a client for the libpod REST API. The address is either a path to the
+// podman unix socket (optionally prefixed with unix://) or a http(s):// / tcp:// url.
+func New(address string) (types.Client, error) {
+	if !strings.Contains(address, "://") {
+		address = "unix://" + address
+	}
+
+	u, err := url.Parse(address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse podman address %s: %w", address, err)
+	}
+
+	switch u.Scheme {
+	case "unix":
+		socket := u.Path
+		transport := &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", socket)
+			},
+		}
+		return &client{http: &http.Client{Transport: transport}, baseUrl: "http://d", socket: address}, nil
+	case "tcp", "http":
+		return &client{http: &http.Client{}, baseUrl: "http://" + u.Host, socket: address}, nil
+	case "https":
+		return &client{http: &http.Client{}, baseUrl: "https://" + u.Host, socket: address}, nil
+	default:
+		return nil, fmt.Errorf("unsupported podman address scheme: %s", u.Scheme)
+	}
+}
+
+func (c *client) List(ctx context.Context) ([]types.Container, error) {
+	filters, err := json.Marshal(map[string][]string{"status": {"restarting", "running", "paused"}})
+	if err != nil {
+		return nil, err
+	}
+
+	var listResult []listContainer
+	if err := c.do(ctx, http.MethodGet, "/containers/json", url.Values{"filters": {string(filters)}}, &listResult); err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	result := make([]types.Container, 0, len(listResult))
+	for _, listed := range listResult {
+		container := newContainer(listed)
+		// the list lacks the network addresses and the health, so the container is inspected for them
+		if r, err := c.inspect(ctx, listed.Id); err == nil {
+			container.networks = toNetworks(r)
+			container.lifecycle.Health = toHealth(r)
+		} else {
+			log.Debug().Err(err).Str("containerId", listed.Id).Msg("failed to inspect container")
+		}
+		result = append(result, container)
+	}
+	return result, nil
+}
+
+func (c *client) Info(ctx context.Context, id string) (types.Container, error) {
+	r, err := c.inspect(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get podman container %s: %w", id, err)
+	}
+	return newContainerFromInspect(r), nil
+}
+
+func (c *client) GetPid(ctx context.Context, id string) (int, error) {
+	r, err := c.inspect(ctx, id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to inspect container: %w", err)
+	}
+	return r.State.Pid, nil
+}
+
+func (c *client) inspect(ctx context.Context, id string) (inspectContainer, error) {
+	var r inspectContainer
+	err := c.do(ctx, http.MethodGet, "/containers/"+url.PathEscape(id)+"/json", nil, &r)
+	return r, err
+}
+
+func (c *client) Pause(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodPost, "/containers/"+url.PathEscape(id)+"/pause", nil, nil)
+}
+
+func (c *client) Unpause(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodPost, "/containers/"+url.PathEscape(id)+"/unpause", nil, nil)
+}
+
+func (c *client) Stop(ctx context.Context, id string, graceful bool, gracePeriod time.Duration) (bool, error) {
+	query := url.Values{}
+	query.Set("timeout", "0")
+	if graceful {
+		query.Set("timeout", strconv.Itoa(types.TimeoutSeconds(gracePeriod)))
+	}
+
+	if err := c.do(ctx, http.MethodPost, "/containers/"+url.PathEscape(id)+"/stop", query, nil); err != nil {
+		return false, fmt.Errorf("failed to stop container %s: %w", id, err)
+	}
+
+	r, err := c.inspect(ctx, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to inspect stopped container %s: %w", id, err)
+	}
+	return r.State.ExitCode == types.ExitCodeKilled, nil
+}
+
+func (c *client) Restart(ctx context.Context, id string, graceful bool, timeout time.Duration) error {
+	query := url.Values{"t": {"0"}}
+	if graceful {
+		query.Set("t", strconv.Itoa(types.TimeoutSeconds(timeout)))
+	}
+
+	if err := c.do(ctx, http.MethodPost, "/containers/"+url.PathEscape(id)+"/restart", query, nil); err != nil {
+		return fmt.Errorf("failed to restart container %s: %w", id, err)
+	}
+	return nil
+}
+
+func (c *client) Version(ctx context.Context) (string, error) {
+	var version struct {
+		Version string `json:"Version"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/version", nil, &version); err != nil {
+		return "", err
+	}
+	return version.Version, nil
+}
+
+func (c *client) Watch(ctx context.Context) (<-chan types.Event, error) {
+	filters, err := json.Marshal(map[string][]string{
+		"type":  {"container"},
+		"event": {"create", "start", "died", "remove", "pause", "unpause", "health_status"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{"stream": {"true"}, "filters": {string(filters)}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseUrl+apiPrefix+"/events?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch podman events: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer func() { _ = resp.Body.Close() }()
+		return nil, fmt.Errorf("failed to watch podman events: %w", newApiError(resp))
+	}
+
+	result := make(chan types.Event)
+	go func() {
+		defer close(result)
+		defer func() { _ = resp.Body.Close() }()
+
+		decoder := json.NewDecoder(resp.Body)
+		for {
+			var msg struct {
+				Action       string `json:"Action"`
+				HealthStatus string `json:"HealthStatus"`
+				Actor        struct {
+					ID string `json:"ID"`
+				} `json:"Actor"`
+			}
+			if err := decoder.Decode(&msg); err != nil {
+				if ctx.Err() == nil {
+					log.Debug().Err(err).Msg("podman event stream closed")
+				}
+				return
+			}
+
+			event := types.Event{ContainerId: msg.Actor.ID}
+			switch msg.Action {
+			case "create":
+				event.Type = types.EventTypeCreate
+			case "start":
+				event.Type = types.EventTypeStart
+			case "died":
+				event.Type = types.EventTypeDie
+			case "remove":
+				event.Type = types.EventTypeDestroy
+			case "pause":
+				event.Type = types.EventTypePause
+			case "unpause":
+				event.Type = types.EventTypeUnpause
+			case "health_status":
+				if msg.HealthStatus == "" {
+					continue
+				}
+				event.Type, event.Health = types.EventTypeHealthStatus, msg.HealthStatus
+			default:
+				continue
+			}
+
+			select {
+			case result <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return result, nil
+}
+
+func (c *client) Close() error {
+	c.http.CloseIdleConnections()
+	return nil
+}
+
+func (c *client) do(ctx context.Context, method, path string, query url.Values, result any) error {
+	u := c.baseUrl + apiPrefix + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	// 304 is returned by libpod when the container is already in the requested state
+	if resp.StatusCode == http.StatusNotModified {
+		return nil
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return newApiError(resp)
+	}
+
+	if result == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+type apiError struct {
+	StatusCode int
+	Message    string `json:"message"`
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("podman api error (%d): %s", e.StatusCode, e.Message)
+}
+
+func newApiError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	e := &apiError{StatusCode: resp.StatusCode}
+	if err := json.Unmarshal(body, e); err != nil || e.Message == "" {
+		e.Message = strings.TrimSpace(string(body))
+	}
+	return e
+}
+
+type listContainer struct {
+	Id        string            `json:"Id"`
+	Names     []string          `json:"Names"`
+	Image     string            `json:"Image"`
+	Labels    map[string]string `json:"Labels"`
+	State     string            `json:"State"`
+	Pid       int               `json:"Pid"`
+	Networks  []string          `json:"Networks"`
+	Created   time.Time         `json:"Created"`
+	StartedAt int64             `json:"StartedAt"`
+	Restarts  int               `json:"Restarts"`
+	Ports     []struct {
+		HostIp        string `json:"host_ip"`
+		ContainerPort uint16 `json:"container_port"`
+		HostPort      uint16 `json:"host_port"`
+		Range         uint16 `json:"range"`
+		Protocol      string `json:"protocol"`
+	} `json:"Ports"`
+}
+
+type inspectContainer struct {
+	Id           string    `json:"Id"`
+	Name         string    `json:"Name"`
+	ImageName    string    `json:"ImageName"`
+	Created      time.Time `json:"Created"`
+	RestartCount int       `json:"RestartCount"`
+	State        struct {
+		Status    string    `json:"Status"`
+		Pid       int       `json:"Pid"`
+		ExitCode  int       `json:"ExitCode"`
+		StartedAt time.Time `json:"StartedAt"`
+		Health    *struct {
+			Status string `json:"Status"`
+		} `json:"Health"`
+	} `json:"State"`
+	Config struct {
+		Labels map[string]string `json:"Labels"`
+	} `json:"Config"`
+	HostConfig struct {
+		NetworkMode string `json:"NetworkMode"`
+	} `json:"HostConfig"`
+	NetworkSettings struct {
+		Networks map[string]struct {
+			IPAddress         string `json:"IPAddress"`
+			GlobalIPv6Address string `json:"GlobalIPv6Address"`
+		} `json:"Networks"`
+		Ports map[string][]struct {
+			HostIp   string `json:"HostIp"`
+			HostPort string `json:"HostPort"`
+		} `json:"Ports"`
+	} `json:"NetworkSettings"`
+}
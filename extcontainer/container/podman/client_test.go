@@ -0,0 +1,200 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package podman
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubLibpod struct {
+	requests []string
+	exitCode int
+}
+
+func (s *stubLibpod) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v4.0.0/libpod/version", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{"Version": "5.2.1", "ApiVersion": "1.41"})
+	})
+	mux.HandleFunc("GET /v4.0.0/libpod/containers/json", func(w http.ResponseWriter, r *http.Request) {
+		s.requests = append(s.requests, "list "+r.URL.Query().Get("filters"))
+		_ = json.NewEncoder(w).Encode([]map[string]any{
+			{"Id": "abc123", "Names": []string{"web"}, "Image": "docker.io/library/nginx:latest", "Labels": map[string]string{"app": "web"}, "State": "running", "Pid": 42,
+				"Networks": []string{"podman"}, "Created": "2026-10-01T08:00:00Z", "StartedAt": 1790841601, "Restarts": 2, "Ports": []map[string]any{{"host_ip": "", "container_port": 80, "host_port": 8080, "range": 2, "protocol": "tcp"}}},
+		})
+	})
+	mux.HandleFunc("GET /v4.0.0/libpod/containers/{id}/json", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") != "abc123" {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(map[string]any{"cause": "no such container", "message": "no container with name or ID \"" + r.PathValue("id") + "\" found: no such container", "response": 404})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"Id":           "abc123",
+			"Name":         "web",
+			"Image":        "sha256:0815",
+			"ImageName":    "docker.io/library/nginx:latest",
+			"Created":      "2026-10-01T08:00:00Z",
+			"RestartCount": 2,
+			"State":        map[string]any{"Status": "running", "Pid": 42, "ExitCode": s.exitCode, "StartedAt": "2026-10-01T08:00:01Z", "Health": map[string]any{"Status": "healthy"}},
+			"Config":       map[string]any{"Labels": map[string]string{"app": "web"}},
+			"HostConfig":   map[string]any{"NetworkMode": "bridge"},
+			"NetworkSettings": map[string]any{
+				"Networks": map[string]any{"podman": map[string]any{"IPAddress": "10.88.0.2", "GlobalIPv6Address": ""}},
+				"Ports":    map[string]any{"80/tcp": []map[string]string{{"HostIp": "", "HostPort": "8080"}}, "443/tcp": nil},
+			},
+		})
+	})
+	mux.HandleFunc("GET /v4.0.0/libpod/events", func(w http.ResponseWriter, r *http.Request) {
+		s.requests = append(s.requests, "events "+r.URL.Query().Get("filters"))
+		encoder := json.NewEncoder(w)
+		for _, action := range []string{"create", "init", "start", "pause", "unpause", "health_status", "died", "remove"} {
+			_ = encoder.Encode(map[string]any{"Type": "container", "Action": action, "HealthStatus": "unhealthy", "Actor": map[string]any{"ID": "abc123"}})
+		}
+	})
+	mux.HandleFunc("POST /v4.0.0/libpod/containers/{id}/{op}", func(w http.ResponseWriter, r *http.Request) {
+		req := r.PathValue("op") + " " + r.PathValue("id")
+		if t := r.URL.Query().Get("timeout"); t != "" {
+			req += " timeout=" + t
+		}
+		s.requests = append(s.requests, req)
+		if r.PathValue("op") == "unpause" {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	return mux
+}
+
+func newStubClient(t *testing.T) (types.Client, *stubLibpod) {
+	stub := &stubLibpod{}
+	server := httptest.NewServer(stub.handler())
+	t.Cleanup(server.Close)
+
+	c, err := New(server.URL)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+	return c, stub
+}
+
+func Test_client(t *testing.T) {
+	ctx := context.Background()
+	c, stub := newStubClient(t)
+
+	assert.Equal(t, types.RuntimePodman, c.Runtime())
+
+	version, err := c.Version(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "5.2.1", version)
+
+	containers, err := c.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, containers, 1)
+	assert.Equal(t, "abc123", containers[0].Id())
+	assert.Equal(t, "web", containers[0].Name())
+	assert.Equal(t, "docker.io/library/nginx:latest", containers[0].ImageName())
+	assert.Equal(t, map[string]string{"app": "web"}, containers[0].Labels())
+	assert.Equal(t, []types.Network{{Name: "podman", Ips: []string{"10.88.0.2"}}}, containers[0].Networks())
+	assert.Equal(t, []types.Port{{Port: 80, Protocol: "tcp", HostPort: 8080}, {Port: 81, Protocol: "tcp", HostPort: 8081}}, containers[0].Ports())
+	assert.Equal(t, types.Lifecycle{
+		State:        "running",
+		CreatedAt:    time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC),
+		StartedAt:    time.Unix(1790841601, 0),
+		RestartCount: 2,
+		Health:       types.HealthHealthy,
+		Pid:          42,
+	}, containers[0].Lifecycle())
+
+	info, err := c.Info(ctx, "abc123")
+	require.NoError(t, err)
+	assert.Equal(t, "web", info.Name())
+	assert.Equal(t, "docker.io/library/nginx:latest", info.ImageName())
+	assert.Equal(t, []types.Port{{Port: 80, Protocol: "tcp", HostPort: 8080}, {Port: 443, Protocol: "tcp"}}, info.Ports())
+	assert.Equal(t, time.Date(2026, 10, 1, 8, 0, 1, 0, time.UTC), info.Lifecycle().StartedAt)
+
+	pid, err := c.GetPid(ctx, "abc123")
+	require.NoError(t, err)
+	assert.Equal(t, 42, pid)
+
+	require.NoError(t, c.Pause(ctx, "abc123"))
+	require.NoError(t, c.Unpause(ctx, "abc123"))
+	killed, err := c.Stop(ctx, "abc123", true, 60*time.Second)
+	require.NoError(t, err)
+	assert.False(t, killed)
+	stub.exitCode = types.ExitCodeKilled
+	killed, err = c.Stop(ctx, "abc123", false, 60*time.Second)
+	require.NoError(t, err)
+	assert.True(t, killed)
+
+	assert.Equal(t, []string{
+		`list {"status":["restarting","running","paused"]}`,
+		"pause abc123",
+		"unpause abc123",
+		"stop abc123 timeout=60",
+		"stop abc123 timeout=0",
+	}, stub.requests)
+}
+
+func Test_client_watch(t *testing.T) {
+	c, _ := newStubClient(t)
+
+	events, err := c.Watch(t.Context())
+	require.NoError(t, err)
+
+	var received []types.Event
+	for event := range events {
+		received = append(received, event)
+	}
+
+	assert.Equal(t, []types.Event{
+		{Type: types.EventTypeCreate, ContainerId: "abc123"},
+		{Type: types.EventTypeStart, ContainerId: "abc123"},
+		{Type: types.EventTypePause, ContainerId: "abc123"},
+		{Type: types.EventTypeUnpause, ContainerId: "abc123"},
+		{Type: types.EventTypeHealthStatus, ContainerId: "abc123", Health: types.HealthUnhealthy},
+		{Type: types.EventTypeDie, ContainerId: "abc123"},
+		{Type: types.EventTypeDestroy, ContainerId: "abc123"},
+	}, received)
+}
+
+func Test_client_error(t *testing.T) {
+	c, _ := newStubClient(t)
+
+	_, err := c.Info(context.Background(), "unknown")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "podman api error (404)")
+	assert.Contains(t, err.Error(), "no such container")
+}
+
+func Test_client_unixSocket(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "podman.sock")
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+
+	server := httptest.NewUnstartedServer((&stubLibpod{}).handler())
+	_ = server.Listener.Close()
+	server.Listener = listener
+	server.Start()
+	t.Cleanup(server.Close)
+
+	c, err := New(socket)
+	require.NoError(t, err)
+	assert.Equal(t, "unix://"+socket, c.Socket())
+
+	version, err := c.Version(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "5.2.1", version)
+}
@@ -0,0 +1,178 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package podman
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+)
+
+// container implements the types.Container interface for Podman
+type container struct {
+	id        string
+	names     []string
+	imageName string
+	labels    map[string]string
+	networks  []types.Network
+	ports     []types.Port
+	lifecycle types.Lifecycle
+}
+
+func newContainer(c listContainer) *container {
+	var ports []types.Port
+	for _, p := range c.Ports {
+		// a mapping of consecutive ports is listed once with its range
+		for i := uint16(0); i < max(p.Range, 1); i++ {
+			ports = append(ports, types.Port{Port: p.ContainerPort + i, Protocol: p.Protocol, HostIp: p.HostIp, HostPort: p.HostPort + i})
+		}
+	}
+	slices.SortFunc(ports, types.Port.Compare)
+
+	return &container{
+		id:        c.Id,
+		names:     c.Names,
+		imageName: c.Image,
+		labels:    c.Labels,
+		networks:  toListedNetworks(c.Networks),
+		ports:     ports,
+		lifecycle: types.Lifecycle{
+			State:        c.State,
+			CreatedAt:    c.Created,
+			StartedAt:    fromUnix(c.StartedAt),
+			RestartCount: c.Restarts,
+			Pid:          c.Pid,
+		},
+	}
+}
+
+func newContainerFromInspect(c inspectContainer) *container {
+	return &container{
+		id:        c.Id,
+		names:     []string{c.Name},
+		imageName: c.ImageName,
+		labels:    c.Config.Labels,
+		networks:  toNetworks(c),
+		ports:     toPorts(c),
+		lifecycle: types.Lifecycle{
+			State:        c.State.Status,
+			CreatedAt:    c.Created,
+			StartedAt:    c.State.StartedAt,
+			RestartCount: c.RestartCount,
+			Health:       toHealth(c),
+			Pid:          c.State.Pid,
+		},
+	}
+}
+
+func toListedNetworks(names []string) []types.Network {
+	networks := make([]types.Network, 0, len(names))
+	for _, name := range names {
+		networks = append(networks, types.Network{Name: name})
+	}
+	return networks
+}
+
+func fromUnix(seconds int64) time.Time {
+	if seconds <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(seconds, 0)
+}
+
+// toHealth returns the health of the container, empty if the container has no healthcheck
+func toHealth(c inspectContainer) string {
+	if c.State.Health == nil {
+		return ""
+	}
+	switch c.State.Health.Status {
+	case types.HealthStarting, types.HealthHealthy, types.HealthUnhealthy:
+		return c.State.Health.Status
+	}
+	return ""
+}
+
+func toNetworks(c inspectContainer) []types.Network {
+	endpoints := c.NetworkSettings.Networks
+	if len(endpoints) == 0 {
+		if c.HostConfig.NetworkMode == "" {
+			return nil
+		}
+		return []types.Network{{Name: c.HostConfig.NetworkMode}}
+	}
+
+	result := make([]types.Network, 0, len(endpoints))
+	for _, name := range slices.Sorted(maps.Keys(endpoints)) {
+		n := types.Network{Name: name}
+		for _, ip := range []string{endpoints[name].IPAddress, endpoints[name].GlobalIPv6Address} {
+			if ip != "" {
+				n.Ips = append(n.Ips, ip)
+			}
+		}
+		result = append(result, n)
+	}
+	return result
+}
+
+func toPorts(c inspectContainer) []types.Port {
+	var result []types.Port
+	for key, bindings := range c.NetworkSettings.Ports {
+		number, protocol, _ := strings.Cut(key, "/")
+		port, err := strconv.ParseUint(number, 10, 16)
+		if err != nil {
+			continue
+		}
+
+		p := types.Port{Port: uint16(port), Protocol: protocol}
+		if len(bindings) == 0 {
+			result = append(result, p)
+			continue
+		}
+		for _, binding := range bindings {
+			published := p
+			published.HostIp = binding.HostIp
+			if hostPort, err := strconv.ParseUint(binding.HostPort, 10, 16); err == nil {
+				published.HostPort = uint16(hostPort)
+			}
+			result = append(result, published)
+		}
+	}
+	slices.SortFunc(result, types.Port.Compare)
+	return result
+}
+
+func (c *container) Id() string {
+	return c.id
+}
+
+func (c *container) Name() string {
+	if len(c.names) == 0 {
+		return ""
+	}
+	return c.names[0]
+}
+
+func (c *container) ImageName() string {
+	return c.imageName
+}
+
+func (c *container) Labels() map[string]string {
+	return c.labels
+}
+
+func (c *container) Networks() []types.Network {
+	return c.networks
+}
+
+func (c *container) Ports() []types.Port {
+	return c.ports
+}
+
+func (c *container) Lifecycle() types.Lifecycle {
+	return c.lifecycle
+}
@@ -4,7 +4,11 @@
 package types
 
 import (
+	"cmp"
 	"context"
+	"fmt"
+	"math"
+	"time"
 )
 
 type Container interface {
@@ -12,6 +16,82 @@ type Container interface {
 	Name() string
 	ImageName() string
 	Labels() map[string]string
+	// Networks returns the networks the container is attached to, empty if the runtime doesn't report them
+	Networks() []Network
+	// Ports returns the ports exposed by the container
+	Ports() []Port
+	// Lifecycle returns the state, timestamps and health of the container
+	Lifecycle() Lifecycle
+}
+
+// RuntimeContainer is implemented by the containers of a client spanning several runtimes, it tells the runtime the
+// container belongs to.
+type RuntimeContainer interface {
+	Container
+	Runtime() Runtime
+	RuntimeVersion() string
+}
+
+// NamespacedContainer is implemented by the containers of runtimes with namespaces, i.e. containerd
+type NamespacedContainer interface {
+	Namespace() string
+}
+
+const (
+	StateRunning    = "running"
+	StatePaused     = "paused"
+	StateRestarting = "restarting"
+)
+
+const (
+	HealthStarting  = "starting"
+	HealthHealthy   = "healthy"
+	HealthUnhealthy = "unhealthy"
+)
+
+// Lifecycle is the state of the container. Timestamps and the pid are zero and the health is empty if the runtime
+// doesn't report them.
+type Lifecycle struct {
+	State        string
+	CreatedAt    time.Time
+	StartedAt    time.Time
+	RestartCount int
+	Health       string
+	// Pid of the init process of the container, so the discovery needs no Client.GetPid call
+	Pid int
+}
+
+const (
+	NetworkModeHost = "host"
+	NetworkModeNone = "none"
+)
+
+// Network is a network the container is attached to. Containers not attached to a network report their network mode
+// as name instead (host, none or container:<id>).
+type Network struct {
+	Name string
+	Ips  []string
+}
+
+// Port is a port exposed by the container, the host ip and port are set if the port is published on the host.
+type Port struct {
+	Port     uint16
+	Protocol string
+	HostIp   string
+	HostPort uint16
+}
+
+func (p Port) String() string {
+	return fmt.Sprintf("%d/%s", p.Port, p.Protocol)
+}
+
+func (p Port) Compare(o Port) int {
+	return cmp.Or(
+		cmp.Compare(p.Port, o.Port),
+		cmp.Compare(p.Protocol, o.Protocol),
+		cmp.Compare(p.HostIp, o.HostIp),
+		cmp.Compare(p.HostPort, o.HostPort),
+	)
 }
 
 const (
@@ -24,20 +104,56 @@ const (
 	RuntimeCrio               Runtime = "cri-o"
 	DefaultSocketCrio                 = "/var/run/crio/crio.sock"
 	DefaultRuncRootCrio               = "/run/runc"
+	RuntimePodman             Runtime = "podman"
+	DefaultSocketPodman               = "/run/podman/podman.sock"
+	DefaultRuncRootPodman             = "/run/crun"
 )
 
+// TimeoutSeconds converts the timeout of a graceful stop to the whole seconds the runtimes expect. It is rounded up and
+// at least 1, as a timeout of 0 kills the container right away.
+func TimeoutSeconds(timeout time.Duration) int {
+	return max(int(math.Ceil(timeout.Seconds())), 1)
+}
+
+// ExitCodeKilled is the exit code of a container whose main process was killed using SIGKILL
+const ExitCodeKilled = 128 + 9
+
 var (
-	AllRuntimes = []Runtime{RuntimeDocker, RuntimeContainerd, RuntimeCrio}
+	AllRuntimes = []Runtime{RuntimeDocker, RuntimeContainerd, RuntimeCrio, RuntimePodman}
 )
 
 type Runtime string
 
+type EventType string
+
+const (
+	EventTypeCreate       EventType = "create"
+	EventTypeStart        EventType = "start"
+	EventTypeDie          EventType = "die"
+	EventTypeDestroy      EventType = "destroy"
+	EventTypePause        EventType = "pause"
+	EventTypeUnpause      EventType = "unpause"
+	EventTypeHealthStatus EventType = "health_status"
+)
+
+// Event is a container lifecycle event reported by the runtime
+type Event struct {
+	Type        EventType
+	ContainerId string
+	// Health is the new health of the container for EventTypeHealthStatus
+	Health string
+}
+
 type Client interface {
 	// List returns a list of all running containers
 	List(ctx context.Context) ([]Container, error)
 	// Info returns the info of the given container
 	Info(ctx context.Context, id string) (Container, error)
-	Stop(ctx context.Context, id string, graceful bool) error
+	// Stop stops the given container, gracefully within the grace period or killed immediately. It returns whether the
+	// container had to be killed, which most runtimes only tell by the exit code 137 an OOM kill reports as well.
+	Stop(ctx context.Context, id string, graceful bool, gracePeriod time.Duration) (bool, error)
+	// Restart stops the given container, gracefully within the timeout or killed immediately, and starts it again
+	Restart(ctx context.Context, id string, graceful bool, timeout time.Duration) error
 	// Pause pauses the given container
 	Pause(ctx context.Context, id string) error
 	// Unpause unpauses the given container
@@ -46,6 +162,9 @@ type Client interface {
 	Version(ctx context.Context) (string, error)
 	// GetPid returns the pid of the given container
 	GetPid(ctx context.Context, id string) (int, error)
+	// Watch streams container lifecycle events until the context is done.
+	// The channel is closed when the stream breaks, callers have to watch again.
+	Watch(ctx context.Context) (<-chan Event, error)
 	// Close closes the client
 	Close() error
 	// Runtime returns the runtime
@@ -62,6 +181,8 @@ func (runtime Runtime) DefaultSocket() string {
 		return DefaultSocketContainerd
 	case RuntimeCrio:
 		return DefaultSocketCrio
+	case RuntimePodman:
+		return DefaultSocketPodman
 	}
 	return ""
 }
@@ -74,6 +195,8 @@ func (runtime Runtime) DefaultRuncRoot() string {
 		return DefaultRuncRootContainerd
 	case RuntimeCrio:
 		return DefaultRuncRootCrio
+	case RuntimePodman:
+		return DefaultRuncRootPodman
 	}
 	return ""
 }
@@ -0,0 +1,23 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TimeoutSeconds(t *testing.T) {
+	for timeout, want := range map[time.Duration]int{
+		0:                       1,
+		500 * time.Millisecond:  1,
+		time.Second:             1,
+		1500 * time.Millisecond: 2,
+		10 * time.Second:        10,
+	} {
+		assert.Equal(t, want, TimeoutSeconds(timeout), timeout.String())
+	}
+}
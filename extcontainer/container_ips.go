@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// readProcessIps returns the local (non-loopback, non-link-local) addresses of the network namespace of the given
+// process. The /proc/<pid>/net files always show the network namespace of the process, so no namespace needs to be
+// entered.
+func readProcessIps(pid int) ([]net.IP, error) {
+	dir := filepath.Join(procRoot, strconv.Itoa(pid), "net")
+
+	ips, err := readFibTrieLocalIps(filepath.Join(dir, "fib_trie"))
+	if err != nil {
+		return nil, err
+	}
+
+	ipv6, err := readIfInet6Ips(filepath.Join(dir, "if_inet6"))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	ips = append(ips, ipv6...)
+
+	slices.SortFunc(ips, func(a, b net.IP) int { return strings.Compare(a.String(), b.String()) })
+	return slices.CompactFunc(ips, net.IP.Equal), nil
+}
+
+// readFibTrieLocalIps reads the addresses listed as "/32 host LOCAL" in the fib_trie
+func readFibTrieLocalIps(path string) ([]net.IP, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	var ips []net.IP
+	var last string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if address, ok := strings.CutPrefix(line, "|-- "); ok {
+			last = address
+			continue
+		}
+		if line != "/32 host LOCAL" {
+			continue
+		}
+		if ip := net.ParseIP(last); ip != nil && !ip.IsLoopback() {
+			ips = append(ips, ip)
+		}
+	}
+	return ips, scanner.Err()
+}
+
+// readIfInet6Ips reads the addresses with global scope from if_inet6
+func readIfInet6Ips(path string) ([]net.IP, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var ips []net.IP
+	for _, line := range strings.Split(string(content), "\n") {
+		fields := strings.Fields(line)
+		// address, interface index, prefix length, scope, flags, interface name
+		if len(fields) < 6 || fields[3] != "00" {
+			continue
+		}
+		address, err := hex.DecodeString(fields[0])
+		if err != nil || len(address) != net.IPv6len {
+			continue
+		}
+		ips = append(ips, net.IP(address))
+	}
+	return ips, nil
+}
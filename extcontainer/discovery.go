@@ -6,16 +6,23 @@ import (
 	"context"
 	"fmt"
 	dockerparser "github.com/novln/docker-parser"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_commons/ociruntime"
 	"github.com/steadybit/action-kit/go/action_kit_commons/utils"
 	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
 	"github.com/steadybit/discovery-kit/go/discovery_kit_commons"
 	"github.com/steadybit/discovery-kit/go/discovery_kit_sdk"
 	"github.com/steadybit/extension-container/config"
 	"github.com/steadybit/extension-container/extcontainer/container/types"
+	"github.com/steadybit/extension-container/extcontainer/metrics"
 	"github.com/steadybit/extension-kit/extbuild"
+	"maps"
 	"net"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -23,8 +30,29 @@ const (
 	labelPrefixAppKubernetes = "app.kubernetes.io/"
 )
 
+const (
+	reconcileInterval = 30 * time.Second
+	reconcileTimeout  = 5 * time.Minute
+	eventInfoTimeout  = 10 * time.Second
+)
+
+// containerDiscovery keeps the discovered targets up to date using the event stream of the runtime.
+// A full list is done periodically to reconcile missed events and as fallback when the runtime doesn't
+// support watching.
 type containerDiscovery struct {
-	client types.Client
+	client     types.Client
+	ociRuntime ociruntime.OciRuntime
+	fs         fileSystem
+	cgroupV1   bool
+	labelRules []config.LabelAttributeRule
+
+	mu       sync.RWMutex
+	targets  map[string]discovery_kit_api.Target
+	hostname string
+	fqdn     string
+	version  string
+	err      error
+	ready    chan struct{}
 }
 
 var (
@@ -32,13 +60,176 @@ var (
 	_ discovery_kit_sdk.AttributeDescriber = (*containerDiscovery)(nil)
 )
 
-func NewContainerDiscovery(client types.Client) discovery_kit_sdk.TargetDiscovery {
-	discovery := &containerDiscovery{client: client}
-	return discovery_kit_sdk.NewCachedTargetDiscovery(discovery,
-		discovery_kit_sdk.WithTargetsRefreshTimeout(5*time.Minute),
-		discovery_kit_sdk.WithRefreshTargetsNow(),
-		discovery_kit_sdk.WithRefreshTargetsInterval(context.Background(), 30*time.Second),
-	)
+func NewContainerDiscovery(client types.Client, ociRuntime ociruntime.OciRuntime) discovery_kit_sdk.TargetDiscovery {
+	discovery := newContainerDiscovery(client)
+	discovery.ociRuntime = ociRuntime
+	go discovery.run(context.Background(), reconcileInterval)
+	return discovery
+}
+
+func newContainerDiscovery(client types.Client) *containerDiscovery {
+	return &containerDiscovery{
+		client:     client,
+		fs:         osFs,
+		cgroupV1:   isCGroupV1(),
+		labelRules: labelAttributeRules(),
+		targets:    make(map[string]discovery_kit_api.Target),
+		ready:      make(chan struct{}),
+	}
+}
+
+func (d *containerDiscovery) run(ctx context.Context, interval time.Duration) {
+	d.hostname, d.fqdn = d.getHostname()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	events := d.watch(ctx, true)
+	d.reconcile(ctx)
+	close(d.ready)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if events == nil {
+				if events = d.watch(ctx, false); events != nil {
+					log.Info().Msg("Watching container events again.")
+				}
+			}
+			d.reconcile(ctx)
+		case event, ok := <-events:
+			if !ok {
+				log.Warn().Msgf("Container event stream closed, falling back to listing containers every %s.", interval)
+				events = nil
+				continue
+			}
+			d.handleEvent(ctx, event)
+		}
+	}
+}
+
+func (d *containerDiscovery) watch(ctx context.Context, first bool) <-chan types.Event {
+	events, err := d.client.Watch(ctx)
+	if err != nil {
+		if first {
+			log.Warn().Err(err).Msg("Failed to watch container events, falling back to listing containers.")
+		} else {
+			log.Debug().Err(err).Msg("Failed to watch container events.")
+		}
+		return nil
+	}
+	return events
+}
+
+func (d *containerDiscovery) reconcile(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, reconcileTimeout)
+	defer cancel()
+
+	start := time.Now()
+	version, _ := d.client.Version(ctx)
+	containers, err := d.client.List(ctx)
+	metrics.DiscoveryReconcileDuration.Observe(time.Since(start).Seconds())
+	if err != nil {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		d.err = fmt.Errorf("failed to list containers: %w", err)
+		return
+	}
+
+	ignored := map[string]int{}
+	excluded := map[string]int{}
+	targets := make(map[string]discovery_kit_api.Target, len(containers))
+	for _, container := range containers {
+		if reason := ignoreReason(container); reason != "" {
+			ignored[reason]++
+			continue
+		}
+		if rule, ok := excludingRule(d.client, container); ok {
+			log.Debug().Str("containerId", container.Id()).Stringer("rule", rule).Msg("Container excluded by rule.")
+			ignored["exclusion_rule"]++
+			excluded[rule.String()]++
+			continue
+		}
+		target := d.mapTarget(container, d.hostname, d.fqdn, version)
+		d.addCgroupAttributes(ctx, target.Attributes, container)
+		targets[container.Id()] = target
+	}
+
+	metrics.DiscoveryContainersListed.Set(float64(len(containers)))
+	metrics.DiscoveryContainersIgnored.Reset()
+	for reason, count := range ignored {
+		metrics.DiscoveryContainersIgnored.WithLabelValues(reason).Set(float64(count))
+	}
+	metrics.DiscoveryContainersExcluded.Reset()
+	for rule, count := range excluded {
+		metrics.DiscoveryContainersExcluded.WithLabelValues(rule).Set(float64(count))
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.targets = targets
+	d.version = version
+	d.err = nil
+}
+
+func (d *containerDiscovery) handleEvent(ctx context.Context, event types.Event) {
+	switch event.Type {
+	case types.EventTypeStart:
+		ctx, cancel := context.WithTimeout(ctx, eventInfoTimeout)
+		defer cancel()
+
+		container, err := d.client.Info(ctx, event.ContainerId)
+		if err != nil {
+			log.Debug().Err(err).Str("containerId", event.ContainerId).Msg("Failed to get info for started container.")
+			return
+		}
+
+		rule, excluded := excludingRule(d.client, container)
+		if excluded {
+			log.Debug().Str("containerId", container.Id()).Stringer("rule", rule).Msg("Container excluded by rule.")
+		}
+		if excluded || ignoreContainer(container) {
+			d.mu.Lock()
+			defer d.mu.Unlock()
+			delete(d.targets, container.Id())
+			return
+		}
+
+		d.mu.RLock()
+		target := d.mapTarget(container, d.hostname, d.fqdn, d.version)
+		d.mu.RUnlock()
+		d.addCgroupAttributes(ctx, target.Attributes, container)
+
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		d.targets[container.Id()] = target
+	case types.EventTypeDie, types.EventTypeDestroy:
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		delete(d.targets, event.ContainerId)
+	case types.EventTypePause, types.EventTypeUnpause, types.EventTypeHealthStatus:
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		target, ok := d.targets[event.ContainerId]
+		if !ok {
+			return
+		}
+		// the attributes are shared with the targets already returned by DiscoverTargets
+		target.Attributes = maps.Clone(target.Attributes)
+		switch event.Type {
+		case types.EventTypePause:
+			target.Attributes["container.state"] = []string{types.StatePaused}
+		case types.EventTypeUnpause:
+			target.Attributes["container.state"] = []string{types.StateRunning}
+		case types.EventTypeHealthStatus:
+			target.Attributes["container.health"] = []string{event.Health}
+		}
+		d.targets[event.ContainerId] = target
+	default:
+		// created containers are not running yet, they are added on start
+	}
 }
 
 func (d *containerDiscovery) Describe() discovery_kit_api.DiscoveryDescription {
@@ -80,7 +271,7 @@ func (d *containerDiscovery) DescribeTarget() discovery_kit_api.TargetDescriptio
 }
 
 func (d *containerDiscovery) DescribeAttributes() []discovery_kit_api.AttributeDescription {
-	return []discovery_kit_api.AttributeDescription{
+	return append([]discovery_kit_api.AttributeDescription{
 		{
 			Attribute: "container.name",
 			Label:     discovery_kit_api.PluralLabel{One: "Container Name", Other: "Container Names"},
@@ -121,65 +312,155 @@ func (d *containerDiscovery) DescribeAttributes() []discovery_kit_api.AttributeD
 			Attribute: "container.engine.version",
 			Label:     discovery_kit_api.PluralLabel{One: "Container Engine Version", Other: "Container Engine Versions"},
 		},
-	}
+		{
+			Attribute: "container.containerd.namespace",
+			Label:     discovery_kit_api.PluralLabel{One: "Containerd Namespace", Other: "Containerd Namespaces"},
+		},
+		{
+			Attribute: "container.ip",
+			Label:     discovery_kit_api.PluralLabel{One: "Container IP", Other: "Container IPs"},
+		},
+		{
+			Attribute: "container.network",
+			Label:     discovery_kit_api.PluralLabel{One: "Container Network", Other: "Container Networks"},
+		},
+		{
+			Attribute: "container.port",
+			Label:     discovery_kit_api.PluralLabel{One: "Container Port", Other: "Container Ports"},
+		},
+		{
+			Attribute: "container.state",
+			Label:     discovery_kit_api.PluralLabel{One: "Container State", Other: "Container States"},
+		},
+		{
+			Attribute: "container.created_at",
+			Label:     discovery_kit_api.PluralLabel{One: "Container Created At", Other: "Container Created At"},
+		},
+		{
+			Attribute: "container.started_at",
+			Label:     discovery_kit_api.PluralLabel{One: "Container Started At", Other: "Container Started At"},
+		},
+		{
+			Attribute: "container.restart_count",
+			Label:     discovery_kit_api.PluralLabel{One: "Container Restart Count", Other: "Container Restart Counts"},
+		},
+		{
+			Attribute: "container.health",
+			Label:     discovery_kit_api.PluralLabel{One: "Container Health", Other: "Container Health"},
+		},
+		{
+			Attribute: "container.limit.cpu.millis",
+			Label:     discovery_kit_api.PluralLabel{One: "Container CPU Limit (millis)", Other: "Container CPU Limits (millis)"},
+		},
+		{
+			Attribute: "container.limit.memory.bytes",
+			Label:     discovery_kit_api.PluralLabel{One: "Container Memory Limit (bytes)", Other: "Container Memory Limits (bytes)"},
+		},
+		{
+			Attribute: "container.limit.cpuset",
+			Label:     discovery_kit_api.PluralLabel{One: "Container CPU Set", Other: "Container CPU Sets"},
+		},
+		{
+			Attribute: "container.limit.pids",
+			Label:     discovery_kit_api.PluralLabel{One: "Container PIDs Limit", Other: "Container PIDs Limits"},
+		},
+		{
+			Attribute: "container.cgroup.path",
+			Label:     discovery_kit_api.PluralLabel{One: "Container Cgroup Path", Other: "Container Cgroup Paths"},
+		},
+		{
+			Attribute: "container.cgroup.version",
+			Label:     discovery_kit_api.PluralLabel{One: "Container Cgroup Version", Other: "Container Cgroup Versions"},
+		},
+	}, d.describeLabelAttributes()...)
 }
 
 func (d *containerDiscovery) DiscoverTargets(ctx context.Context) ([]discovery_kit_api.Target, error) {
-	hostname, fqdn := d.getHostname()
-	version, _ := d.client.Version(ctx)
+	defer func(start time.Time) { metrics.DiscoveryDuration.Observe(time.Since(start).Seconds()) }(time.Now())
 
-	containers, err := d.client.List(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list containers: %w", err)
+	select {
+	case <-d.ready:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("failed to list containers: %w", ctx.Err())
 	}
 
-	targets := make([]discovery_kit_api.Target, 0, len(containers))
-	for _, container := range containers {
-		if ignoreContainer(container) {
-			continue
-		}
-
-		targets = append(targets, d.mapTarget(container, hostname, fqdn, version))
+	d.mu.RLock()
+	if d.err != nil {
+		d.mu.RUnlock()
+		return nil, d.err
 	}
-	return discovery_kit_commons.ApplyAttributeExcludes(targets, config.Config.DiscoveryAttributesExcludes), nil
+	targets := make([]discovery_kit_api.Target, 0, len(d.targets))
+	for _, target := range d.targets {
+		targets = append(targets, target)
+	}
+	d.mu.RUnlock()
+
+	return discovery_kit_commons.ApplyAttributeExcludes(targets, config.Current().DiscoveryAttributesExcludes), nil
 }
 
 func ignoreContainer(container types.Container) bool {
+	return ignoreReason(container) != ""
+}
+
+// excludingRule returns the configured exclusion rule matching the container, excluded containers are neither
+// discovered nor attacked.
+func excludingRule(client types.Client, container types.Container) (config.ContainerRule, bool) {
+	rules := config.Current().ExcludeContainers
+	if len(rules) == 0 {
+		return config.ContainerRule{}, false
+	}
+
+	return rules.Match(containerProperties(client, container))
+}
+
+// containerProperties returns the properties of the container the configured container rules are evaluated against
+func containerProperties(client types.Client, container types.Container) config.ContainerProperties {
+	return config.ContainerProperties{
+		Id:      container.Id(),
+		Name:    container.Name(),
+		Image:   container.ImageName(),
+		Runtime: string(containerRuntime(client, container)),
+		Labels:  container.Labels(),
+	}
+}
+
+// ignoreReason returns why the container is not discovered, or an empty string if it is.
+func ignoreReason(container types.Container) string {
 	labels := container.Labels()
 
 	if hasDisallowedK8sNamespaceLabel(labels) {
-		return true
+		return "disallowed_namespace"
 	}
 
 	if labels["io.cri-containerd.kind"] == "sandbox" {
-		return true
+		return "sandbox"
 	}
 
 	if labels["io.kubernetes.docker.type"] == "podsandbox" {
-		return true
+		return "sandbox"
 	}
 
 	if labels["com.amazonaws.ecs.container-name"] == "~internal~ecs~pause" {
-		return true
+		return "sandbox"
 	}
 
-	if config.Config.DisableDiscoveryExcludes {
-		return false
+	if config.Current().DisableDiscoveryExcludes {
+		return ""
 	}
 
 	if labels["steadybit.com.discovery-disabled"] == "true" {
-		return true
+		return "discovery_disabled"
 	}
 
 	if labels["steadybit.com/discovery-disabled"] == "true" {
-		return true
+		return "discovery_disabled"
 	}
 
 	if labels["com.steadybit.agent"] == "true" {
-		return true
+		return "steadybit_agent"
 	}
 
-	return false
+	return ""
 }
 
 func (d *containerDiscovery) mapTarget(container types.Container, hostname, fqdn string, version string) discovery_kit_api.Target {
@@ -207,16 +488,52 @@ func (d *containerDiscovery) mapTarget(container types.Container, hostname, fqdn
 		}
 	}
 
-	attributes["container.id"] = []string{AddPrefix(container.Id(), d.client.Runtime())}
+	runtime := d.client.Runtime()
+	if rc, ok := container.(types.RuntimeContainer); ok {
+		runtime, version = rc.Runtime(), rc.RuntimeVersion()
+	}
+	attributes["container.id"] = []string{AddPrefix(container.Id(), runtime)}
 	attributes["container.id.stripped"] = []string{container.Id()}
-	attributes["container.engine"] = []string{string(d.client.Runtime())}
+	attributes["container.engine"] = []string{string(runtime)}
+	if namespaced, ok := container.(types.NamespacedContainer); ok && namespaced.Namespace() != "" {
+		attributes["container.containerd.namespace"] = []string{namespaced.Namespace()}
+	}
 	if version != "" {
 		attributes["container.engine.version"] = []string{version}
 	}
 
+	for _, network := range container.Networks() {
+		attributes["container.network"] = append(attributes["container.network"], network.Name)
+		attributes["container.ip"] = append(attributes["container.ip"], network.Ips...)
+	}
+	var ports []string
+	for _, port := range container.Ports() {
+		ports = append(ports, port.String())
+	}
+	// the ports are sorted, a port published on several host ports is only listed once
+	if ports = slices.Compact(ports); len(ports) > 0 {
+		attributes["container.port"] = ports
+	}
+
+	lifecycle := container.Lifecycle()
+	if lifecycle.State != "" {
+		attributes["container.state"] = []string{lifecycle.State}
+	}
+	if !lifecycle.CreatedAt.IsZero() {
+		attributes["container.created_at"] = []string{lifecycle.CreatedAt.UTC().Format(time.RFC3339)}
+	}
+	if !lifecycle.StartedAt.IsZero() {
+		attributes["container.started_at"] = []string{lifecycle.StartedAt.UTC().Format(time.RFC3339)}
+	}
+	attributes["container.restart_count"] = []string{strconv.Itoa(lifecycle.RestartCount)}
+	if lifecycle.Health != "" {
+		attributes["container.health"] = []string{lifecycle.Health}
+	}
+
 	labels := container.Labels()
 	for key, value := range labels {
 		addLabelOrK8sAttribute(attributes, key, value)
+		d.addMappedLabelAttributes(attributes, key, value)
 	}
 
 	label := container.Id()
@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+)
+
+// cgroupInfo are the cgroup and resource limits of a container. Unlimited resources are -1 (or empty for the cpuset).
+type cgroupInfo struct {
+	version     string
+	path        string
+	cpuMillis   int
+	memoryBytes int
+	cpuset      string
+	pidsMax     int
+}
+
+// addCgroupAttributes adds the cgroup and resource limit attributes of the container. The attributes are left out when
+// the cgroup of the container can't be read.
+func (d *containerDiscovery) addCgroupAttributes(ctx context.Context, attributes map[string][]string, container types.Container) {
+	if d.ociRuntime == nil {
+		return
+	}
+
+	containerId := container.Id()
+	// the cgroup path in /proc/<pid>/cgroup is relative to the cgroup namespace of the extension, the oci runtime
+	// knows the absolute one.
+	processInfo, err := getProcessInfoForContainer(ctx, d.ociRuntime, RemovePrefix(containerId))
+	if err != nil {
+		log.Debug().Err(err).Str("containerId", containerId).Msg("Failed to get process info to read cgroup limits.")
+		return
+	}
+
+	info, err := readCgroupInfo(processInfo.CGroupPath, d.cgroupV1, d.fs)
+	if err != nil {
+		log.Debug().Err(err).Str("containerId", containerId).Msg("Failed to read cgroup limits.")
+		return
+	}
+
+	attributes["container.cgroup.version"] = []string{info.version}
+	attributes["container.cgroup.path"] = []string{info.path}
+	if info.cpuMillis >= 0 {
+		attributes["container.limit.cpu.millis"] = []string{strconv.Itoa(info.cpuMillis)}
+	}
+	if info.memoryBytes >= 0 {
+		attributes["container.limit.memory.bytes"] = []string{strconv.Itoa(info.memoryBytes)}
+	}
+	if info.cpuset != "" {
+		attributes["container.limit.cpuset"] = []string{info.cpuset}
+	}
+	if info.pidsMax >= 0 {
+		attributes["container.limit.pids"] = []string{strconv.Itoa(info.pidsMax)}
+	}
+}
+
+func readCgroupInfo(path string, v1 bool, fs fileSystem) (cgroupInfo, error) {
+	if path == "" {
+		return cgroupInfo{}, errors.New("no cgroup path")
+	}
+
+	if !v1 {
+		return cgroupInfo{
+			version:     "v2",
+			path:        path,
+			cpuMillis:   readCGroupV2CpuLimit(path, fs),
+			memoryBytes: readCGroupV2MemLimit(path, fs),
+			cpuset:      readCgroupValue(fs, "/sys/fs/cgroup", path, "cpuset.cpus.effective"),
+			pidsMax:     readCgroupMax(fs, "/sys/fs/cgroup", path, "pids.max"),
+		}, nil
+	}
+
+	return cgroupInfo{
+		version:     "v1",
+		path:        path,
+		cpuMillis:   readCGroupV1CpuLimit(path, fs),
+		memoryBytes: readCGroupV1MemLimit(path, fs),
+		cpuset:      readCgroupValue(fs, "/sys/fs/cgroup/cpuset", path, "cpuset.cpus"),
+		pidsMax:     readCgroupMax(fs, "/sys/fs/cgroup/pids", path, "pids.max"),
+	}, nil
+}
+
+func readCgroupValue(fs fileSystem, root, path, file string) string {
+	content, err := fs.ReadFile(filepath.Join(root, path, file))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(content))
+}
+
+// readCgroupMax reads a limit which is either a number or "max", returns -1 for unlimited or unreadable limits
+func readCgroupMax(fs fileSystem, root, path, file string) int {
+	value := readCgroupValue(fs, root, path, file)
+	if value == "" || value == "max" {
+		return -1
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil {
+		return -1
+	}
+	return limit
+}
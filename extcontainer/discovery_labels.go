@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/steadybit/extension-container/config"
+)
+
+// labelAttributeRules returns the configured label mappings, the enabled presets first
+func labelAttributeRules() []config.LabelAttributeRule {
+	var rules []config.LabelAttributeRule
+	for _, preset := range config.Config.LabelAttributePresets {
+		rules = append(rules, preset.Rules()...)
+	}
+	return append(rules, config.Config.LabelAttributes...)
+}
+
+// addMappedLabelAttributes adds the attributes of all rules matching the label
+func (d *containerDiscovery) addMappedLabelAttributes(attributes map[string][]string, key, value string) {
+	for _, rule := range d.labelRules {
+		if attribute, ok := rule.Map(key); ok && !slices.Contains(attributes[attribute], value) {
+			attributes[attribute] = append(attributes[attribute], value)
+		}
+	}
+}
+
+// describeLabelAttributes describes the attributes of the label mappings, which don't depend on the label key
+func (d *containerDiscovery) describeLabelAttributes() []discovery_kit_api.AttributeDescription {
+	var result []discovery_kit_api.AttributeDescription
+	for _, rule := range d.labelRules {
+		attribute, ok := rule.Attribute()
+		if !ok || slices.ContainsFunc(result, func(a discovery_kit_api.AttributeDescription) bool { return a.Attribute == attribute }) {
+			continue
+		}
+		label := attributeLabel(attribute)
+		result = append(result, discovery_kit_api.AttributeDescription{
+			Attribute: attribute,
+			Label:     discovery_kit_api.PluralLabel{One: label, Other: label + "s"},
+		})
+	}
+	return result
+}
+
+// attributeLabel derives a label from the attribute name, e.g. "Nomad Job Name" for nomad.job.name
+func attributeLabel(attribute string) string {
+	words := strings.FieldsFunc(attribute, func(r rune) bool { return r == '.' || r == '_' || r == '-' })
+	for i, word := range words {
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		words[i] = string(runes)
+	}
+	return strings.Join(words, " ")
+}
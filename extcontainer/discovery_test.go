@@ -0,0 +1,344 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/steadybit/action-kit/go/action_kit_commons/ociruntime"
+	"github.com/steadybit/extension-container/config"
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+	"github.com/steadybit/extension-container/extcontainer/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type watchingClient struct {
+	*MockedClient
+	mu     sync.Mutex
+	listed []string
+	events chan types.Event
+}
+
+func (c *watchingClient) setListed(ids ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.listed = ids
+}
+
+func (c *watchingClient) List(_ context.Context) ([]types.Container, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var result []types.Container
+	for _, container := range c.c {
+		if slices.Contains(c.listed, container.id) {
+			result = append(result, container)
+		}
+	}
+	return result, nil
+}
+
+func (c *watchingClient) Watch(_ context.Context) (<-chan types.Event, error) {
+	return c.events, nil
+}
+
+func (c *watchingClient) Version(_ context.Context) (string, error) {
+	return "1.0.0", nil
+}
+
+func (c *watchingClient) Runtime() types.Runtime {
+	return types.RuntimeDocker
+}
+
+func discoveredIds(t *testing.T, d *containerDiscovery) []string {
+	targets, err := d.DiscoverTargets(t.Context())
+	require.NoError(t, err)
+	ids := make([]string, 0, len(targets))
+	for _, target := range targets {
+		ids = append(ids, target.Id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+func Test_containerDiscovery_events(t *testing.T) {
+	config.Config.Hostname = "localhost"
+	defer func() { config.Config.Hostname = "" }()
+
+	client := &watchingClient{
+		MockedClient: newMockedContainerClient().
+			addContainer("running", nil).
+			addContainer("new", nil).
+			addContainer("ignored", map[string]string{"steadybit.com/discovery-disabled": "true"}),
+		listed: []string{"running"},
+		events: make(chan types.Event),
+	}
+	d := newContainerDiscovery(client)
+	go d.run(t.Context(), time.Hour)
+
+	assert.Equal(t, []string{"running"}, discoveredIds(t, d))
+
+	client.events <- types.Event{Type: types.EventTypeCreate, ContainerId: "new"}
+	client.events <- types.Event{Type: types.EventTypeStart, ContainerId: "new"}
+	client.events <- types.Event{Type: types.EventTypeStart, ContainerId: "ignored"}
+	client.events <- types.Event{Type: types.EventTypeDie, ContainerId: "running"}
+	assert.Eventually(t, func() bool {
+		return slices.Equal([]string{"new"}, discoveredIds(t, d))
+	}, time.Second, 10*time.Millisecond)
+
+	client.events <- types.Event{Type: types.EventTypePause, ContainerId: "new"}
+	client.events <- types.Event{Type: types.EventTypeHealthStatus, ContainerId: "new", Health: types.HealthUnhealthy}
+	client.events <- types.Event{Type: types.EventTypeUnpause, ContainerId: "running"}
+	assert.Eventually(t, func() bool {
+		targets, err := d.DiscoverTargets(t.Context())
+		return err == nil && len(targets) == 1 &&
+			slices.Equal([]string{types.StatePaused}, targets[0].Attributes["container.state"]) &&
+			slices.Equal([]string{types.HealthUnhealthy}, targets[0].Attributes["container.health"])
+	}, time.Second, 10*time.Millisecond)
+
+	client.events <- types.Event{Type: types.EventTypeDestroy, ContainerId: "new"}
+	assert.Eventually(t, func() bool {
+		return len(discoveredIds(t, d)) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func Test_containerDiscovery_reconcilesWithoutEvents(t *testing.T) {
+	config.Config.Hostname = "localhost"
+	defer func() { config.Config.Hostname = "" }()
+
+	client := &watchingClient{
+		MockedClient: newMockedContainerClient().addContainer("running", nil),
+		events:       make(chan types.Event),
+	}
+	close(client.events)
+
+	d := newContainerDiscovery(client)
+	go d.run(t.Context(), 50*time.Millisecond)
+
+	assert.Empty(t, discoveredIds(t, d))
+
+	client.setListed("running")
+	assert.Eventually(t, func() bool {
+		return slices.Equal([]string{"running"}, discoveredIds(t, d))
+	}, time.Second, 10*time.Millisecond)
+}
+
+func Test_containerDiscovery_reconcileExcludesContainers(t *testing.T) {
+	require.NoError(t, config.Config.ExcludeContainers.Decode("label:app=sidecar; name=mocked-db"))
+	defer func() { config.Config.ExcludeContainers = nil }()
+
+	client := &watchingClient{
+		MockedClient: newMockedContainerClient().
+			addContainer("web", map[string]string{"app": "web"}).
+			addContainer("proxy", map[string]string{"app": "sidecar"}).
+			addContainer("db", nil),
+	}
+	client.setListed("web", "proxy", "db")
+
+	d := newContainerDiscovery(client)
+	d.reconcile(t.Context())
+	assert.Equal(t, []string{"web"}, slices.Collect(maps.Keys(d.targets)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.DiscoveryContainersExcluded.WithLabelValues("label:app=sidecar")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.DiscoveryContainersExcluded.WithLabelValues("name=mocked-db")))
+}
+
+func Test_containerDiscovery_mapTargetNetworks(t *testing.T) {
+	client := &watchingClient{MockedClient: newMockedContainerClient()}
+	d := newContainerDiscovery(client)
+
+	target := d.mapTarget(mockedContainer{
+		id: "web",
+		networks: []types.Network{
+			{Name: "backend", Ips: []string{"172.18.0.2", "fd00::2"}},
+			{Name: "frontend", Ips: []string{"172.19.0.2"}},
+		},
+		ports: []types.Port{
+			{Port: 80, Protocol: "tcp", HostIp: "0.0.0.0", HostPort: 8080},
+			{Port: 80, Protocol: "tcp", HostIp: "::", HostPort: 8080},
+			{Port: 443, Protocol: "tcp"},
+		},
+	}, "localhost", "localhost", "")
+
+	assert.Equal(t, []string{"backend", "frontend"}, target.Attributes["container.network"])
+	assert.Equal(t, []string{"172.18.0.2", "fd00::2", "172.19.0.2"}, target.Attributes["container.ip"])
+	assert.Equal(t, []string{"80/tcp", "443/tcp"}, target.Attributes["container.port"])
+}
+
+type podmanContainer struct {
+	mockedContainer
+}
+
+func (c podmanContainer) Runtime() types.Runtime {
+	return types.RuntimePodman
+}
+
+func (c podmanContainer) RuntimeVersion() string {
+	return "5.2.1"
+}
+
+func Test_containerDiscovery_mapTargetRuntimeContainer(t *testing.T) {
+	client := &watchingClient{MockedClient: newMockedContainerClient()}
+	d := newContainerDiscovery(client)
+
+	target := d.mapTarget(podmanContainer{mockedContainer{id: "web"}}, "localhost", "localhost", "1.0.0")
+	assert.Equal(t, []string{"podman://web"}, target.Attributes["container.id"])
+	assert.Equal(t, []string{"podman"}, target.Attributes["container.engine"])
+	assert.Equal(t, []string{"5.2.1"}, target.Attributes["container.engine.version"])
+}
+
+type namespacedContainer struct {
+	mockedContainer
+	namespace string
+}
+
+func (c namespacedContainer) Namespace() string {
+	return c.namespace
+}
+
+func Test_containerDiscovery_mapTargetNamespace(t *testing.T) {
+	client := &watchingClient{MockedClient: newMockedContainerClient()}
+	d := newContainerDiscovery(client)
+
+	target := d.mapTarget(namespacedContainer{mockedContainer{id: "web"}, "moby"}, "localhost", "localhost", "")
+	assert.Equal(t, []string{"moby"}, target.Attributes["container.containerd.namespace"])
+
+	target = d.mapTarget(mockedContainer{id: "web"}, "localhost", "localhost", "")
+	assert.NotContains(t, target.Attributes, "container.containerd.namespace")
+}
+
+func Test_containerDiscovery_mapTargetLabelAttributes(t *testing.T) {
+	var compose config.LabelAttributePreset
+	require.NoError(t, compose.Decode("compose"))
+	var custom config.LabelAttributeRule
+	require.NoError(t, custom.Decode("com.acme.*=acme.*"))
+	config.Config.LabelAttributePresets = []config.LabelAttributePreset{compose}
+	config.Config.LabelAttributes = []config.LabelAttributeRule{custom}
+	defer func() {
+		config.Config.LabelAttributePresets = nil
+		config.Config.LabelAttributes = nil
+	}()
+
+	client := &watchingClient{MockedClient: newMockedContainerClient()}
+	d := newContainerDiscovery(client)
+
+	target := d.mapTarget(mockedContainer{id: "web", labels: map[string]string{
+		"com.docker.compose.service": "web",
+		"com.acme.team":              "checkout",
+	}}, "localhost", "localhost", "")
+	assert.Equal(t, []string{"web"}, target.Attributes["compose.service"])
+	assert.Equal(t, []string{"web"}, target.Attributes["container.label.com.docker.compose.service"])
+	assert.Equal(t, []string{"checkout"}, target.Attributes["acme.team"])
+
+	var described []string
+	for _, description := range d.DescribeAttributes() {
+		described = append(described, description.Attribute)
+	}
+	assert.Contains(t, described, "compose.service")
+	assert.NotContains(t, described, "acme.*")
+}
+
+func Test_attributeLabel(t *testing.T) {
+	assert.Equal(t, "Nomad Task Group Name", attributeLabel("nomad.task_group.name"))
+	assert.Equal(t, "Compose Container Number", attributeLabel("compose.container_number"))
+}
+
+func Test_containerDiscovery_mapTargetLifecycle(t *testing.T) {
+	client := &watchingClient{MockedClient: newMockedContainerClient()}
+	d := newContainerDiscovery(client)
+
+	target := d.mapTarget(mockedContainer{
+		id: "web",
+		lifecycle: types.Lifecycle{
+			State:        types.StatePaused,
+			CreatedAt:    time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC),
+			StartedAt:    time.Date(2026, 10, 1, 10, 0, 0, 0, time.FixedZone("CEST", 2*60*60)),
+			RestartCount: 3,
+			Health:       types.HealthUnhealthy,
+		},
+	}, "localhost", "localhost", "")
+
+	assert.Equal(t, []string{"paused"}, target.Attributes["container.state"])
+	assert.Equal(t, []string{"2026-10-01T08:00:00Z"}, target.Attributes["container.created_at"])
+	assert.Equal(t, []string{"2026-10-01T08:00:00Z"}, target.Attributes["container.started_at"])
+	assert.Equal(t, []string{"3"}, target.Attributes["container.restart_count"])
+	assert.Equal(t, []string{"unhealthy"}, target.Attributes["container.health"])
+
+	target = d.mapTarget(mockedContainer{id: "unknown"}, "localhost", "localhost", "")
+	assert.NotContains(t, target.Attributes, "container.started_at")
+	assert.NotContains(t, target.Attributes, "container.health")
+}
+
+func Test_containerDiscovery_cgroupAttributes(t *testing.T) {
+	getProcessInfoForContainer = func(_ context.Context, _ ociruntime.OciRuntime, containerId string, _ ...specs.LinuxNamespaceType) (ociruntime.LinuxProcessInfo, error) {
+		switch containerId {
+		case "limited":
+			return ociruntime.LinuxProcessInfo{Pid: 100, CGroupPath: "/kubepods/burstable/pod1/limited"}, nil
+		case "unlimited":
+			return ociruntime.LinuxProcessInfo{Pid: 200, CGroupPath: "/docker/unlimited"}, nil
+		}
+		return ociruntime.LinuxProcessInfo{}, fmt.Errorf("container %s not running", containerId)
+	}
+	defer func() {
+		getProcessInfoForContainer = getProcessInfoForContainerImpl
+	}()
+
+	d := newContainerDiscovery(&watchingClient{MockedClient: newMockedContainerClient()})
+	d.ociRuntime = newMockedRunc()
+	d.cgroupV1 = false
+	d.fs = mockFilesystem{values: map[string]string{
+		// relative to the cgroup namespace of the extension, must not be used
+		"/proc/100/cgroup": "0::/../../kubepods/burstable/pod1/limited\n",
+		"/sys/fs/cgroup/kubepods/burstable/pod1/limited/cpu.max":               "50000 100000\n",
+		"/sys/fs/cgroup/kubepods/burstable/pod1/limited/memory.max":            "268435456\n",
+		"/sys/fs/cgroup/kubepods/burstable/pod1/limited/cpuset.cpus.effective": "0-3\n",
+		"/sys/fs/cgroup/kubepods/burstable/pod1/limited/pids.max":              "1024\n",
+		"/sys/fs/cgroup/docker/unlimited/cpu.max":                              "max 100000\n",
+		"/sys/fs/cgroup/docker/unlimited/memory.max":                           "max\n",
+		"/sys/fs/cgroup/docker/unlimited/pids.max":                             "max\n",
+	}}
+
+	limited := map[string][]string{}
+	d.addCgroupAttributes(t.Context(), limited, mockedContainer{id: "limited"})
+	assert.Equal(t, map[string][]string{
+		"container.cgroup.version":     {"v2"},
+		"container.cgroup.path":        {"/kubepods/burstable/pod1/limited"},
+		"container.limit.cpu.millis":   {"500"},
+		"container.limit.memory.bytes": {"268435456"},
+		"container.limit.cpuset":       {"0-3"},
+		"container.limit.pids":         {"1024"},
+	}, limited)
+
+	unlimited := map[string][]string{}
+	d.addCgroupAttributes(t.Context(), unlimited, mockedContainer{id: "unlimited"})
+	assert.Equal(t, map[string][]string{
+		"container.cgroup.version": {"v2"},
+		"container.cgroup.path":    {"/docker/unlimited"},
+	}, unlimited)
+
+	stopped := map[string][]string{}
+	d.addCgroupAttributes(t.Context(), stopped, mockedContainer{id: "stopped"})
+	assert.Empty(t, stopped)
+}
+
+func Test_readCgroupInfo_v1(t *testing.T) {
+	fs := mockFilesystem{values: map[string]string{
+		"/sys/fs/cgroup/cpu,cpuacct/docker/abc/cpu.cfs_quota_us":  "200000\n",
+		"/sys/fs/cgroup/cpu,cpuacct/docker/abc/cpu.cfs_period_us": "100000\n",
+		"/sys/fs/cgroup/memory/docker/abc/memory.limit_in_bytes":  "536870912\n",
+		"/sys/fs/cgroup/cpuset/docker/abc/cpuset.cpus":            "0,2\n",
+		"/sys/fs/cgroup/pids/docker/abc/pids.max":                 "max\n",
+	}}
+
+	info, err := readCgroupInfo("/docker/abc", true, fs)
+	require.NoError(t, err)
+	assert.Equal(t, cgroupInfo{version: "v1", path: "/docker/abc", cpuMillis: 2000, memoryBytes: 536870912, cpuset: "0,2", pidsMax: -1}, info)
+}
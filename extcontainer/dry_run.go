@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_commons/network"
+	"github.com/steadybit/action-kit/go/action_kit_commons/network/netfault"
+	"github.com/steadybit/extension-container/config"
+	"github.com/steadybit/extension-kit/extutil"
+)
+
+// dryRunParameter lets a single execution run as dry run, all executions do when config.Config.DryRun is set
+var dryRunParameter = action_kit_api.ActionParameter{
+	Name:         "dryRun",
+	Label:        "Dry Run",
+	Description:  new("Prepare the attack as usual, but only report what would be done instead of changing the container."),
+	Type:         action_kit_api.ActionParameterTypeBoolean,
+	DefaultValue: new("false"),
+	Required:     new(false),
+	Advanced:     new(true),
+	Order:        new(200),
+}
+
+// dryRunPlanner must be implemented by all actions wrapped using withJournal. It describes what start would do with
+// the prepared state, without changing anything.
+type dryRunPlanner[T any] interface {
+	dryRunPlan(state *T) ([]string, error)
+}
+
+func isDryRun(request action_kit_api.PrepareActionRequestBody) bool {
+	return config.Config.DryRun || extutil.ToBool(request.Config["dryRun"])
+}
+
+// dryRunStartResult reports the plan of a dry run. As nothing is started, the attack no longer counts against the
+// blast radius guard.
+func dryRunStartResult(executionId uuid.UUID, targetLabel string, plan []string) *action_kit_api.StartResult {
+	attackGuard.release(executionId)
+
+	messages := make([]action_kit_api.Message, 0, len(plan)+1)
+	messages = append(messages, action_kit_api.Message{
+		Level:   extutil.Ptr(action_kit_api.Info),
+		Message: fmt.Sprintf("Dry run, nothing is changed on container %s. The attack would:", targetLabel),
+	})
+	for _, step := range plan {
+		messages = append(messages, action_kit_api.Message{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: step,
+		})
+	}
+	return &action_kit_api.StartResult{Messages: &messages}
+}
+
+func dryRunStopResult(targetLabel string) *action_kit_api.StopResult {
+	return &action_kit_api.StopResult{
+		Messages: &[]action_kit_api.Message{
+			{
+				Level:   extutil.Ptr(action_kit_api.Info),
+				Message: fmt.Sprintf("Dry run, nothing to revert on container %s.", targetLabel),
+			},
+		},
+	}
+}
+
+// describeNetworkOpts describes the traffic filter and the interfaces of the network settings, networkCommands records
+// the commands applying them.
+func describeNetworkOpts(opts netfault.Opts) []string {
+	var filter netfault.Filter
+	var interfaces []string
+	switch o := opts.(type) {
+	case *netfault.BlackholeOpts:
+		filter = o.Filter
+	case *netfault.DelayOpts:
+		filter, interfaces = o.Filter, o.Interfaces
+	case *netfault.LimitBandwidthOpts:
+		filter, interfaces = o.Filter, o.Interfaces
+	case *netfault.CorruptPackagesOpts:
+		filter, interfaces = o.Filter, o.Interfaces
+	case *netfault.PackageLossOpts:
+		filter, interfaces = o.Filter, o.Interfaces
+	case *netfault.TcpResetOpts:
+		filter, interfaces = o.Filter, o.Interfaces
+	}
+
+	var plan []string
+	if len(interfaces) > 0 {
+		plan = append(plan, fmt.Sprintf("affect the interfaces %s", strings.Join(interfaces, ", ")))
+	}
+	for _, nwp := range filter.Include {
+		plan = append(plan, "include traffic to/from "+describeNetWithPortRange(nwp))
+	}
+	for _, nwp := range filter.Exclude {
+		plan = append(plan, "exclude traffic to/from "+describeNetWithPortRange(nwp))
+	}
+	return plan
+}
+
+func describeNetWithPortRange(nwp network.NetWithPortRange) string {
+	result := fmt.Sprintf("%s ports %d-%d", nwp.Net.String(), nwp.PortRange.From, nwp.PortRange.To)
+	if nwp.Comment != "" {
+		result += fmt.Sprintf(" (%s)", nwp.Comment)
+	}
+	return result
+}
@@ -0,0 +1,29 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"context"
+
+	"github.com/steadybit/action-kit/go/action_kit_commons/network/netfault"
+	"github.com/steadybit/extension-container/extcontainer/audit"
+)
+
+// networkCommands returns the commands netfault runs in the sidecar to apply the network settings. netfault is run
+// against a recordingRuntime without a runtime to delegate to, so the commands are the ones of a real attack without
+// anything being executed.
+func networkCommands(ctx context.Context, sidecar netfault.SidecarOpts, opts netfault.Opts) ([]string, error) {
+	runner := netfault.NewRuncRunner(&recordingRuntime{}, sidecar)
+	applyCtx, commands := audit.WithCommands(ctx)
+	snap, err := netfault.Apply(applyCtx, runner, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// reverting forgets the settings in netfault, the revert commands are not part of the plan
+	if err := netfault.Revert(ctx, runner, opts, snap); err != nil {
+		return nil, err
+	}
+	return commands(), nil
+}
@@ -0,0 +1,30 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"net"
+	"testing"
+
+	"github.com/steadybit/action-kit/go/action_kit_commons/network"
+	"github.com/steadybit/action-kit/go/action_kit_commons/network/netfault"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_describeNetworkOpts(t *testing.T) {
+	_, cidr, _ := net.ParseCIDR("10.0.0.0/8")
+	opts := &netfault.DelayOpts{
+		Filter: netfault.Filter{
+			Include: []network.NetWithPortRange{{Net: *cidr, PortRange: network.PortRange{From: 80, To: 80}}},
+			Exclude: []network.NetWithPortRange{{Net: *cidr, PortRange: network.PortRange{From: 8080, To: 8081}, Comment: "ext. port"}},
+		},
+		Interfaces: []string{"eth0", "eth1"},
+	}
+
+	assert.Equal(t, []string{
+		"affect the interfaces eth0, eth1",
+		"include traffic to/from 10.0.0.0/8 ports 80-80",
+		"exclude traffic to/from 10.0.0.0/8 ports 8080-8081 (ext. port)",
+	}, describeNetworkOpts(opts))
+}
@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/exthttp"
+)
+
+// RevertReport tells whether an attack was reverted by the extension itself.
+type RevertReport struct {
+	ExecutionId uuid.UUID `json:"executionId"`
+	ActionId    string    `json:"actionId"`
+	ContainerId string    `json:"containerId"`
+	TargetLabel string    `json:"targetLabel"`
+	Reverted    bool      `json:"reverted"`
+	Error       string    `json:"error,omitempty"`
+	Messages    []string  `json:"messages,omitempty"`
+	Commands    []string  `json:"commands,omitempty"`
+}
+
+type EmergencyStopResult struct {
+	Attacks  []RevertReport `json:"attacks"`
+	Reverted int            `json:"reverted"`
+	Failed   int            `json:"failed"`
+}
+
+// emergencyStopper is implemented by actions keeping attacks running that aren't journaled, so they are stopped by an
+// emergency stop as well.
+type emergencyStopper interface {
+	emergencyStop(ctx context.Context, reason string) []RevertReport
+}
+
+var emergencyStoppers = struct {
+	sync.Mutex
+	byAction map[string]emergencyStopper
+}{byAction: map[string]emergencyStopper{}}
+
+func registerEmergencyStopper(actionId string, stopper emergencyStopper) {
+	emergencyStoppers.Lock()
+	defer emergencyStoppers.Unlock()
+	emergencyStoppers.byAction[actionId] = stopper
+}
+
+func newRevertReport(actionId string, ref journalRef, result *action_kit_api.StopResult, err error) RevertReport {
+	report := RevertReport{
+		ExecutionId: ref.ExecutionId,
+		ActionId:    actionId,
+		ContainerId: ref.ContainerId,
+		TargetLabel: ref.TargetLabel,
+		Reverted:    true,
+	}
+	if result != nil {
+		report.Messages = messageTexts(result.Messages)
+		if result.Error != nil {
+			report.Reverted = false
+			report.Error = result.Error.Title
+		}
+	}
+	if err != nil {
+		report.Reverted = false
+		report.Error = err.Error()
+	}
+	return report
+}
+
+// EmergencyStop reverts all attacks active on this host, without waiting for the platform: the journaled attacks are
+// reverted using their journaled state and the running process killers are stopped. State attacks (stop, restart and
+// signal container) have nothing to revert. The platform is told about the reverted attacks on its next status call.
+func EmergencyStop(ctx context.Context, reason string) EmergencyStopResult {
+	log.Warn().Str("reason", reason).Msg("Emergency stop, reverting all active attacks.")
+
+	reports := journal.revertAll(ctx, reason)
+
+	emergencyStoppers.Lock()
+	stoppers := make([]emergencyStopper, 0, len(emergencyStoppers.byAction))
+	for _, stopper := range emergencyStoppers.byAction {
+		stoppers = append(stoppers, stopper)
+	}
+	emergencyStoppers.Unlock()
+	for _, stopper := range stoppers {
+		reports = append(reports, stopper.emergencyStop(ctx, reason)...)
+	}
+
+	result := EmergencyStopResult{Attacks: reports}
+	for _, report := range reports {
+		if report.Reverted {
+			result.Reverted++
+		} else {
+			result.Failed++
+		}
+	}
+	log.Warn().Int("reverted", result.Reverted).Int("failed", result.Failed).Msg("Emergency stop completed.")
+	return result
+}
+
+// NewEmergencyStopHandler returns the handler of the emergency stop endpoint, which has to be called using POST and the
+// token as bearer token.
+func NewEmergencyStopHandler(token string) exthttp.Handler {
+	return func(w http.ResponseWriter, r *http.Request, _ []byte) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		provided, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			log.Warn().Str("remoteAddr", r.RemoteAddr).Msg("Rejected unauthenticated emergency stop.")
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		// keep reverting when the client disconnects
+		exthttp.WriteBody(w, EmergencyStop(context.WithoutCancel(r.Context()), "emergency stop requested by "+r.RemoteAddr))
+	}
+}
+
+// HandleEmergencyStopSignal triggers an emergency stop on SIGUSR2. SIGUSR1 can't be used, as the extension-kit shuts
+// down the extension on it.
+func HandleEmergencyStopSignal() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGUSR2)
+	go func() {
+		for range signals {
+			result := EmergencyStop(context.Background(), "emergency stop requested by SIGUSR2")
+			for _, report := range result.Attacks {
+				event := log.Info()
+				if !report.Reverted {
+					event = log.Error().Str("error", report.Error)
+				}
+				event.Str("actionId", report.ActionId).
+					Str("executionId", report.ExecutionId.String()).
+					Str("target", report.TargetLabel).
+					Bool("reverted", report.Reverted).
+					Msg("Emergency stop of attack.")
+			}
+		}
+	}()
+}
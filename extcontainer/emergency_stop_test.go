@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_EmergencyStop_revertsJournaledAttacks(t *testing.T) {
+	withTestJournal(t)
+	inner, state := startJournaled(t, 30000)
+
+	result := EmergencyStop(t.Context(), "test")
+	require.Len(t, result.Attacks, 1)
+	assert.Equal(t, RevertReport{
+		ExecutionId: state.ExecutionId,
+		ActionId:    "journal-test",
+		ContainerId: "container-1",
+		TargetLabel: "container-1",
+		Reverted:    true,
+		Commands:    []string{"revert container-1"},
+	}, result.Attacks[0])
+	assert.Equal(t, 1, result.Reverted)
+	assert.Equal(t, 0, result.Failed)
+	assert.Equal(t, int32(1), inner.stopped.Load())
+	assert.Empty(t, journal.list())
+
+	// the platform is told on its next status call and the attack isn't stopped twice
+	action := journal.actions["journal-test"].(*journaledAction[journalTestState, *journalTestState])
+	status, err := action.Status(t.Context(), &state)
+	require.NoError(t, err)
+	assert.True(t, status.Completed)
+	require.NotNil(t, status.Error)
+	assert.Contains(t, status.Error.Title, "reverted by the extension: test")
+
+	stop, err := action.Stop(t.Context(), &state)
+	require.NoError(t, err)
+	assert.Contains(t, (*stop.Messages)[0].Message, "already reverted by the extension: test")
+	assert.Equal(t, int32(1), inner.stopped.Load())
+
+	assert.Empty(t, EmergencyStop(t.Context(), "test").Attacks)
+}
+
+func Test_EmergencyStop_stopsProcessKillers(t *testing.T) {
+	withTestJournal(t)
+	withFakeProc(t, map[int]fakeProcess{
+		1:   {pidNs: "pid:[1]", comm: "supervisord", cmdline: "supervisord\x00"},
+		101: {pidNs: "pid:[1]", comm: "nginx", cmdline: "nginx\x00"},
+	})
+	withKilledProcesses(t)
+
+	client := &restartingClient{pids: map[string]int{"test-container": 1}}
+	client.addContainer("test-container", nil)
+	action := NewKillProcessContainerAction(client).(*killProcessAction)
+	state := action.NewEmptyState()
+	_, err := action.Prepare(t.Context(), &state, action_kit_api.PrepareActionRequestBody{
+		ExecutionId: uuid.New(),
+		Config:      map[string]any{"processName": "nginx", "mode": "repeat", "interval": 1000, "duration": 10000},
+		Target:      &action_kit_api.Target{Attributes: map[string][]string{"container.id": {"test-container"}}},
+	})
+	require.NoError(t, err)
+	_, err = action.Start(t.Context(), &state)
+	require.NoError(t, err)
+
+	result := EmergencyStop(t.Context(), "test")
+	require.Len(t, result.Attacks, 1)
+	assert.Equal(t, state.ExecutionId, result.Attacks[0].ExecutionId)
+	assert.True(t, result.Attacks[0].Reverted)
+	assert.Equal(t, []string{"Stopped killing processes, killed nginx 1x (respawned 0x) in container test-container"}, result.Attacks[0].Messages)
+
+	status, err := action.Status(t.Context(), &state)
+	require.NoError(t, err)
+	assert.True(t, status.Completed)
+	require.NotNil(t, status.Error)
+
+	_, err = action.Stop(t.Context(), &state)
+	require.NoError(t, err)
+}
+
+func Test_NewEmergencyStopHandler(t *testing.T) {
+	withTestJournal(t)
+	handler := NewEmergencyStopHandler("secret")
+
+	tests := []struct {
+		name          string
+		method        string
+		authorization string
+		wantCode      int
+	}{
+		{name: "get", method: http.MethodGet, authorization: "Bearer secret", wantCode: http.StatusMethodNotAllowed},
+		{name: "no token", method: http.MethodPost, wantCode: http.StatusUnauthorized},
+		{name: "wrong token", method: http.MethodPost, authorization: "Bearer other", wantCode: http.StatusUnauthorized},
+		{name: "not bearer", method: http.MethodPost, authorization: "secret", wantCode: http.StatusUnauthorized},
+		{name: "token", method: http.MethodPost, authorization: "Bearer secret", wantCode: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, "/emergency-stop", nil)
+			if tt.authorization != "" {
+				r.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+			handler(w, r, nil)
+			require.Equal(t, tt.wantCode, w.Code)
+
+			if tt.wantCode == http.StatusOK {
+				var result EmergencyStopResult
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+				assert.Empty(t, result.Attacks)
+			}
+		})
+	}
+}
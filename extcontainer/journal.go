@@ -0,0 +1,639 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-container/extcontainer/audit"
+	"github.com/steadybit/extension-kit/extutil"
+)
+
+const (
+	// orphanTimeout is how long a recovered attack waits for the platform to call status or stop before it is reverted.
+	// The agent calls the status endpoint of an active attack at least every 15s, so a minute is plenty.
+	orphanTimeout = 1 * time.Minute
+	revertTimeout = 2 * time.Minute
+)
+
+// attackJournal records every started attack, so attacks can be reverted when the extension is restarted mid-attack.
+//
+// The action_kit_sdk keeps the state of active actions in memory only. When the extension is killed, tc rules,
+// stress sidecars, fill-disk sidecars or frozen cgroups stay behind until the platform calls stop - and if the
+// platform execution is gone as well, nobody ever will. Therefore each started attack is also written to
+// <stateDir>/<executionId>.json and removed again once the attack was stopped successfully.
+//
+// On startup RecoverAttacks adopts all journaled attacks (e.g. restoring the netns tracker). Attacks the platform
+// still cares about keep running and are stopped by the platform as usual. Attacks that didn't see a status or stop
+// call within the orphanTimeout, or whose deadline has long passed, are reverted by calling Stop with the journaled
+// state.
+type attackJournal struct {
+	mu       sync.Mutex
+	dir      string
+	entries  map[uuid.UUID]*JournalEntry
+	actions  map[string]journaledStopper
+	pending  map[uuid.UUID]pendingAttack
+	seen     map[uuid.UUID]bool
+	stopping map[uuid.UUID]bool
+	// reverted holds the reason of the attacks reverted by the extension itself
+	reverted map[uuid.UUID]string
+}
+
+type JournalEntry struct {
+	ExecutionId uuid.UUID       `json:"executionId"`
+	ActionId    string          `json:"actionId"`
+	ContainerId string          `json:"containerId"`
+	TargetLabel string          `json:"targetLabel"`
+	StartedAt   time.Time       `json:"startedAt"`
+	Deadline    *time.Time      `json:"deadline,omitempty"`
+	Parameters  map[string]any  `json:"parameters,omitempty"`
+	State       json.RawMessage `json:"state"`
+}
+
+// journalPrepareTimeout is how long a prepared attack is kept pending without being started, like guardPrepareTimeout
+const journalPrepareTimeout = guardPrepareTimeout
+
+// pendingAttack is a prepared attack, which is journaled once it is started
+type pendingAttack struct {
+	preparedAt time.Time
+	duration   time.Duration
+	parameters map[string]any
+}
+
+// journalRef identifies the attack of an action state in the journal.
+type journalRef struct {
+	ExecutionId uuid.UUID
+	ContainerId string
+	TargetLabel string
+	// DryRun attacks are neither started nor journaled
+	DryRun bool
+}
+
+// journaledState must be implemented by the states of all actions wrapped using withJournal or WithAudit.
+type journaledState interface {
+	journalRef() journalRef
+}
+
+// describingState can be implemented by the states of journaled actions to add details (e.g. the sidecar) to the
+// attack listed by ActiveAttacks.
+type describingState interface {
+	describeAttack(attack *ActiveAttack)
+}
+
+// adoptingState can be implemented by the states of journaled actions to restore in-memory bookkeeping for an
+// attack that was started by a previous run of the extension.
+type adoptingState interface {
+	adoptAfterRestart()
+}
+
+type journaledStopper interface {
+	adopt(raw json.RawMessage) error
+	stop(ctx context.Context, raw json.RawMessage) (*action_kit_api.StopResult, error)
+	describe(raw json.RawMessage, attack *ActiveAttack) error
+}
+
+var journal = newAttackJournal()
+
+func newAttackJournal() *attackJournal {
+	return &attackJournal{
+		entries:  map[uuid.UUID]*JournalEntry{},
+		actions:  map[string]journaledStopper{},
+		pending:  map[uuid.UUID]pendingAttack{},
+		seen:     map[uuid.UUID]bool{},
+		stopping: map[uuid.UUID]bool{},
+		reverted: map[uuid.UUID]string{},
+	}
+}
+
+// InitAttackJournal enables writing the journal to the given directory. The journal is kept in memory only when dir
+// is empty.
+func InitAttackJournal(dir string) error {
+	if dir == "" {
+		log.Warn().Msg("No state dir configured, the attack journal is kept in memory only and attacks can't be reverted after a restart of the extension. Set STEADYBIT_EXTENSION_STATE_DIR to a persistent directory.")
+		return nil
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create state dir %s: %w", dir, err)
+	}
+
+	journal.mu.Lock()
+	defer journal.mu.Unlock()
+	journal.dir = dir
+	return nil
+}
+
+// RecoverAttacks adopts or reverts the attacks journaled by a previous run of the extension. Must be called after all
+// actions have been registered.
+func RecoverAttacks(ctx context.Context) {
+	entries, err := journal.load()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to read attack journal.")
+	}
+
+	for _, entry := range entries {
+		journal.recover(ctx, entry, time.Now())
+	}
+}
+
+func (j *attackJournal) recover(ctx context.Context, entry *JournalEntry, now time.Time) {
+	logger := log.With().
+		Str("actionId", entry.ActionId).
+		Str("executionId", entry.ExecutionId.String()).
+		Str("target", entry.TargetLabel).
+		Logger()
+
+	j.mu.Lock()
+	stopper, ok := j.actions[entry.ActionId]
+	if ok {
+		j.entries[entry.ExecutionId] = entry
+	}
+	j.mu.Unlock()
+
+	if !ok {
+		logger.Warn().Msg("Dropping journaled attack of unknown action.")
+		j.removeFile(entry.ExecutionId)
+		return
+	}
+
+	if err := stopper.adopt(entry.State); err != nil {
+		logger.Warn().Err(err).Msg("Failed to adopt journaled attack.")
+	}
+	attackStarted(entry.ActionId, entry.ExecutionId, nil)
+
+	if entry.Deadline != nil && now.After(entry.Deadline.Add(orphanTimeout)) {
+		logger.Info().Msg("Reverting journaled attack, its deadline has passed.")
+		go j.revert(ctx, entry.ExecutionId, "deadline passed")
+		return
+	}
+
+	logger.Info().Msgf("Adopted journaled attack, reverting it if the platform doesn't check in within %s.", orphanTimeout)
+	time.AfterFunc(orphanTimeout, func() {
+		if j.wasSeen(entry.ExecutionId) {
+			return
+		}
+		j.revert(ctx, entry.ExecutionId, "platform execution is gone")
+	})
+}
+
+// revert stops a journaled attack using its journaled state. Returns false if the attack isn't journaled or is being
+// stopped already.
+func (j *attackJournal) revert(ctx context.Context, executionId uuid.UUID, reason string) (RevertReport, bool) {
+	j.mu.Lock()
+	entry, ok := j.entries[executionId]
+	if !ok || j.stopping[executionId] {
+		j.mu.Unlock()
+		return RevertReport{}, false
+	}
+	stopper := j.actions[entry.ActionId]
+	j.stopping[executionId] = true
+	j.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, revertTimeout)
+	defer cancel()
+
+	logger := log.With().
+		Str("actionId", entry.ActionId).
+		Str("executionId", executionId.String()).
+		Str("target", entry.TargetLabel).
+		Str("reason", reason).
+		Logger()
+
+	ctx, commands := audit.WithCommands(ctx)
+	result, err := stopper.stop(ctx, entry.State)
+	report := newRevertReport(entry.ActionId, journalRef{ExecutionId: executionId, ContainerId: entry.ContainerId, TargetLabel: entry.TargetLabel}, result, err)
+	report.Commands = commands()
+	recordRevert(report)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to revert journaled attack, manual cleanup might be necessary.")
+	} else {
+		logger.Info().Msg("Reverted journaled attack.")
+	}
+	attackEnded(executionId, err != nil)
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.reverted[executionId] = reason
+	delete(j.stopping, executionId)
+	j.removeLocked(executionId)
+	return report, true
+}
+
+// revertAll reverts all journaled attacks at once, ordered by their start time.
+func (j *attackJournal) revertAll(ctx context.Context, reason string) []RevertReport {
+	entries := j.list()
+	reports := make([]*RevertReport, len(entries))
+	var wg sync.WaitGroup
+	for i, entry := range entries {
+		wg.Go(func() {
+			if report, ok := j.revert(ctx, entry.ExecutionId, reason); ok {
+				reports[i] = &report
+			}
+		})
+	}
+	wg.Wait()
+
+	result := make([]RevertReport, 0, len(reports))
+	for _, report := range reports {
+		if report != nil {
+			result = append(result, *report)
+		}
+	}
+	return result
+}
+
+func (j *attackJournal) register(actionId string, stopper journaledStopper) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.actions[actionId] = stopper
+}
+
+func (j *attackJournal) prepared(executionId uuid.UUID, duration time.Duration, parameters map[string]any) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	now := time.Now()
+	maps.DeleteFunc(j.pending, func(_ uuid.UUID, attack pendingAttack) bool {
+		return now.Sub(attack.preparedAt) > journalPrepareTimeout
+	})
+	j.pending[executionId] = pendingAttack{preparedAt: now, duration: duration, parameters: parameters}
+}
+
+// record adds or updates the journal entry of an attack.
+func (j *attackJournal) record(actionId string, ref journalRef, state any) {
+	raw, err := json.Marshal(state)
+	if err != nil {
+		log.Warn().Err(err).Str("executionId", ref.ExecutionId.String()).Msg("Failed to serialize state for attack journal.")
+		return
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	entry, ok := j.entries[ref.ExecutionId]
+	if ok && string(entry.State) == string(raw) {
+		return
+	}
+	if !ok {
+		entry = &JournalEntry{
+			ExecutionId: ref.ExecutionId,
+			ActionId:    actionId,
+			StartedAt:   time.Now(),
+		}
+		if pending, ok := j.pending[ref.ExecutionId]; ok {
+			if pending.duration > 0 {
+				entry.Deadline = extutil.Ptr(entry.StartedAt.Add(pending.duration))
+			}
+			entry.Parameters = pending.parameters
+		}
+		delete(j.pending, ref.ExecutionId)
+		j.entries[ref.ExecutionId] = entry
+	}
+	entry.ContainerId = ref.ContainerId
+	entry.TargetLabel = ref.TargetLabel
+	entry.State = raw
+
+	if err := j.writeFile(entry); err != nil {
+		log.Warn().Err(err).Str("executionId", ref.ExecutionId.String()).Msg("Failed to write attack journal.")
+	}
+}
+
+func (j *attackJournal) touch(executionId uuid.UUID) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.seen[executionId] = true
+}
+
+func (j *attackJournal) wasSeen(executionId uuid.UUID) bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.seen[executionId]
+}
+
+// revertReason returns why the attack was reverted by the extension itself, if it was.
+func (j *attackJournal) revertReason(executionId uuid.UUID) (string, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	reason, ok := j.reverted[executionId]
+	return reason, ok
+}
+
+// beginStop returns false if the attack was (or is being) reverted by the journal already.
+func (j *attackJournal) beginStop(executionId uuid.UUID) bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.seen[executionId] = true
+	if _, ok := j.reverted[executionId]; ok || j.stopping[executionId] {
+		return false
+	}
+	j.stopping[executionId] = true
+	return true
+}
+
+func (j *attackJournal) endStop(executionId uuid.UUID, stopped bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	delete(j.stopping, executionId)
+	delete(j.pending, executionId)
+	if stopped {
+		j.removeLocked(executionId)
+	}
+}
+
+// describe adds the details of the journaled state to the attack.
+func (j *attackJournal) describe(entry JournalEntry, attack *ActiveAttack) error {
+	j.mu.Lock()
+	stopper, ok := j.actions[entry.ActionId]
+	j.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	return stopper.describe(entry.State, attack)
+}
+
+// list returns all journaled attacks ordered by start time.
+func (j *attackJournal) list() []JournalEntry {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	result := make([]JournalEntry, 0, len(j.entries))
+	for _, entry := range j.entries {
+		result = append(result, *entry)
+	}
+	sort.Slice(result, func(a, b int) bool {
+		return result[a].StartedAt.Before(result[b].StartedAt)
+	})
+	return result
+}
+
+func (j *attackJournal) removeLocked(executionId uuid.UUID) {
+	delete(j.entries, executionId)
+	delete(j.seen, executionId)
+	if j.dir != "" {
+		if err := os.Remove(j.path(executionId)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			log.Warn().Err(err).Str("executionId", executionId.String()).Msg("Failed to remove attack from journal.")
+		}
+	}
+}
+
+func (j *attackJournal) removeFile(executionId uuid.UUID) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.removeLocked(executionId)
+}
+
+func (j *attackJournal) path(executionId uuid.UUID) string {
+	return filepath.Join(j.dir, executionId.String()+".json")
+}
+
+func (j *attackJournal) writeFile(entry *JournalEntry) error {
+	if j.dir == "" {
+		return nil
+	}
+
+	content, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	// write to a temp file and rename it, so a crash never leaves a truncated entry behind
+	tmp, err := os.CreateTemp(j.dir, ".journal-*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(content); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), j.path(entry.ExecutionId))
+}
+
+func (j *attackJournal) load() ([]*JournalEntry, error) {
+	j.mu.Lock()
+	dir := j.dir
+	j.mu.Unlock()
+
+	if dir == "" {
+		return nil, nil
+	}
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	var entries []*JournalEntry
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".json") || strings.HasPrefix(file.Name(), ".") {
+			continue
+		}
+
+		content, err := os.ReadFile(filepath.Join(dir, file.Name()))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		var entry JournalEntry
+		if err := json.Unmarshal(content, &entry); err != nil {
+			errs = append(errs, fmt.Errorf("invalid journal entry %s: %w", file.Name(), err))
+			_ = os.Remove(filepath.Join(dir, file.Name()))
+			continue
+		}
+		entries = append(entries, &entry)
+	}
+	return entries, errors.Join(errs...)
+}
+
+// journaledAction wraps an action to record its attacks in the journal.
+type journaledAction[T any, PT interface {
+	*T
+	journaledState
+}] struct {
+	action action_kit_sdk.ActionWithStop[T]
+	id     string
+}
+
+var _ action_kit_sdk.ActionWithStatus[PauseActionState] = (*journaledAction[PauseActionState, *PauseActionState])(nil)
+var _ action_kit_sdk.ActionWithStop[PauseActionState] = (*journaledAction[PauseActionState, *PauseActionState])(nil)
+
+// withJournal records the attacks of the given action in the journal. The action has to implement Stop, which is
+// used to revert orphaned attacks after a restart, and dryRunPlanner, which is used instead of Start for dry runs.
+func withJournal[T any, PT interface {
+	*T
+	journaledState
+}](action action_kit_sdk.Action[T]) action_kit_sdk.Action[T] {
+	withStop, ok := action.(action_kit_sdk.ActionWithStop[T])
+	if !ok {
+		panic(fmt.Sprintf("action %s must implement stop to be journaled", action.Describe().Id))
+	}
+	if _, ok := action.(dryRunPlanner[T]); !ok {
+		panic(fmt.Sprintf("action %s must implement dry run to be journaled", action.Describe().Id))
+	}
+
+	a := &journaledAction[T, PT]{action: withStop, id: action.Describe().Id}
+	journal.register(a.id, a)
+	return a
+}
+
+func (a *journaledAction[T, PT]) NewEmptyState() T {
+	return a.action.NewEmptyState()
+}
+
+func (a *journaledAction[T, PT]) Describe() action_kit_api.ActionDescription {
+	description := a.action.Describe()
+	// mirror the status the action_kit_sdk adds for actions with stop only, as the wrapper always implements status
+	if _, ok := a.action.(action_kit_sdk.ActionWithStatus[T]); !ok && description.Status == nil {
+		description.Status = &action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: new("15s"),
+		}
+	}
+	description.Parameters = append(description.Parameters, dryRunParameter)
+	return description
+}
+
+func (a *journaledAction[T, PT]) Prepare(ctx context.Context, state *T, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	result, err := a.action.Prepare(ctx, state, request)
+	if err != nil {
+		return result, err
+	}
+	if err := attackGuard.admit(ctx, a.id, request); err != nil {
+		return nil, err
+	}
+	if !isDryRun(request) {
+		journal.prepared(request.ExecutionId, time.Duration(extutil.ToInt64(request.Config["duration"]))*time.Millisecond, request.Config)
+	}
+	return result, nil
+}
+
+func (a *journaledAction[T, PT]) Start(ctx context.Context, state *T) (*action_kit_api.StartResult, error) {
+	if ref := PT(state).journalRef(); ref.DryRun {
+		plan, err := a.action.(dryRunPlanner[T]).dryRunPlan(state)
+		if err != nil {
+			attackGuard.release(ref.ExecutionId)
+			return nil, err
+		}
+		return dryRunStartResult(ref.ExecutionId, ref.TargetLabel, plan), nil
+	}
+
+	// record before starting, so a crash during start is reverted as well
+	journal.record(a.id, PT(state).journalRef(), state)
+	result, err := a.action.Start(ctx, state)
+	journal.record(a.id, PT(state).journalRef(), state)
+	attackStarted(a.id, PT(state).journalRef().ExecutionId, errors.Join(err, startError(result)))
+	return result, err
+}
+
+func (a *journaledAction[T, PT]) Status(ctx context.Context, state *T) (*action_kit_api.StatusResult, error) {
+	ref := PT(state).journalRef()
+	if ref.DryRun {
+		return &action_kit_api.StatusResult{Completed: false}, nil
+	}
+	journal.touch(ref.ExecutionId)
+	if reason, ok := journal.revertReason(ref.ExecutionId); ok {
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Error: &action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("Attack on %s was reverted by the extension: %s", ref.TargetLabel, reason),
+				Status: extutil.Ptr(action_kit_api.Failed),
+			},
+		}, nil
+	}
+
+	withStatus, ok := a.action.(action_kit_sdk.ActionWithStatus[T])
+	if !ok {
+		return &action_kit_api.StatusResult{Completed: false}, nil
+	}
+
+	result, err := withStatus.Status(ctx, state)
+	journal.record(a.id, ref, state)
+	if err != nil || (result != nil && result.Completed) {
+		attackEnded(ref.ExecutionId, err != nil || result.Error != nil)
+	}
+	return result, err
+}
+
+func (a *journaledAction[T, PT]) Stop(ctx context.Context, state *T) (*action_kit_api.StopResult, error) {
+	ref := PT(state).journalRef()
+	if ref.DryRun {
+		return dryRunStopResult(ref.TargetLabel), nil
+	}
+	if !journal.beginStop(ref.ExecutionId) {
+		message := fmt.Sprintf("Attack on %s is being reverted by the extension.", ref.TargetLabel)
+		if reason, ok := journal.revertReason(ref.ExecutionId); ok {
+			message = fmt.Sprintf("Attack on %s was already reverted by the extension: %s.", ref.TargetLabel, reason)
+		}
+		return &action_kit_api.StopResult{
+			Messages: &[]action_kit_api.Message{
+				{
+					Level:   extutil.Ptr(action_kit_api.Info),
+					Message: message,
+				},
+			},
+		}, nil
+	}
+
+	result, err := a.action.Stop(ctx, state)
+	journal.endStop(ref.ExecutionId, err == nil)
+	attackEnded(ref.ExecutionId, err != nil || (result != nil && result.Error != nil))
+	return result, err
+}
+
+func (a *journaledAction[T, PT]) adopt(raw json.RawMessage) error {
+	state := a.action.NewEmptyState()
+	if err := json.Unmarshal(raw, &state); err != nil {
+		return err
+	}
+	if adopting, ok := any(&state).(adoptingState); ok {
+		adopting.adoptAfterRestart()
+	}
+	return nil
+}
+
+func (a *journaledAction[T, PT]) stop(ctx context.Context, raw json.RawMessage) (*action_kit_api.StopResult, error) {
+	state := a.action.NewEmptyState()
+	if err := json.Unmarshal(raw, &state); err != nil {
+		return nil, err
+	}
+	return a.action.Stop(ctx, &state)
+}
+
+func (a *journaledAction[T, PT]) describe(raw json.RawMessage, attack *ActiveAttack) error {
+	state := a.action.NewEmptyState()
+	if err := json.Unmarshal(raw, &state); err != nil {
+		return err
+	}
+	if describing, ok := any(&state).(describingState); ok {
+		describing.describeAttack(attack)
+	}
+	return nil
+}
+
+func startError(result *action_kit_api.StartResult) error {
+	if result == nil || result.Error == nil {
+		return nil
+	}
+	return errors.New(result.Error.Title)
+}
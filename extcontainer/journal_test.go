@@ -0,0 +1,244 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-container/extcontainer/audit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type journalTestState struct {
+	ExecutionId uuid.UUID
+	ContainerId string
+	Applied     bool
+	DryRun      bool `json:",omitempty"`
+}
+
+func (s *journalTestState) journalRef() journalRef {
+	return journalRef{ExecutionId: s.ExecutionId, ContainerId: s.ContainerId, TargetLabel: s.ContainerId, DryRun: s.DryRun}
+}
+
+type journalTestAction struct {
+	prepareErr error
+	stopped    atomic.Int32
+	lastRun    atomic.Pointer[journalTestState]
+}
+
+func (a *journalTestAction) NewEmptyState() journalTestState {
+	return journalTestState{}
+}
+
+func (a *journalTestAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{Id: "journal-test", Stop: new(action_kit_api.MutatingEndpointReference{})}
+}
+
+func (a *journalTestAction) Prepare(_ context.Context, state *journalTestState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	state.ExecutionId = request.ExecutionId
+	state.ContainerId = "container-1"
+	state.DryRun = isDryRun(request)
+	return nil, a.prepareErr
+}
+
+func (a *journalTestAction) Start(ctx context.Context, state *journalTestState) (*action_kit_api.StartResult, error) {
+	state.Applied = true
+	audit.RecordCommands(ctx, "apply "+state.ContainerId)
+	return nil, nil
+}
+
+func (a *journalTestAction) dryRunPlan(state *journalTestState) ([]string, error) {
+	return []string{"apply the test attack on " + state.ContainerId}, nil
+}
+
+func (a *journalTestAction) Stop(ctx context.Context, state *journalTestState) (*action_kit_api.StopResult, error) {
+	a.stopped.Add(1)
+	audit.RecordCommands(ctx, "revert "+state.ContainerId)
+	a.lastRun.Store(state)
+	return nil, nil
+}
+
+func withTestJournal(t *testing.T) string {
+	old := journal
+	journal = newAttackJournal()
+	t.Cleanup(func() { journal = old })
+
+	dir := t.TempDir()
+	require.NoError(t, InitAttackJournal(dir))
+	return dir
+}
+
+func startJournaled(t *testing.T, duration int) (*journalTestAction, journalTestState) {
+	inner := &journalTestAction{}
+	action := withJournal[journalTestState](inner)
+
+	state := action.NewEmptyState()
+	_, err := action.Prepare(t.Context(), &state, action_kit_api.PrepareActionRequestBody{
+		ExecutionId: uuid.New(),
+		Config:      map[string]any{"duration": duration},
+	})
+	require.NoError(t, err)
+	_, err = action.Start(t.Context(), &state)
+	require.NoError(t, err)
+	return inner, state
+}
+
+func Test_journal_recordsUntilStopped(t *testing.T) {
+	dir := withTestJournal(t)
+	_, state := startJournaled(t, 30000)
+
+	file := filepath.Join(dir, state.ExecutionId.String()+".json")
+	require.FileExists(t, file)
+
+	entries, err := journal.load()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "journal-test", entries[0].ActionId)
+	assert.Equal(t, "container-1", entries[0].ContainerId)
+	assert.JSONEq(t, `{"ExecutionId":"`+state.ExecutionId.String()+`","ContainerId":"container-1","Applied":true}`, string(entries[0].State))
+	require.NotNil(t, entries[0].Deadline)
+	assert.WithinDuration(t, entries[0].StartedAt.Add(30*time.Second), *entries[0].Deadline, time.Millisecond)
+
+	action := journal.actions["journal-test"].(*journaledAction[journalTestState, *journalTestState])
+	_, err = action.Stop(t.Context(), &state)
+	require.NoError(t, err)
+
+	assert.NoFileExists(t, file)
+	assert.Empty(t, journal.list())
+}
+
+func Test_journal_dropsPendingAttacks(t *testing.T) {
+	withTestJournal(t)
+	action := withJournal[journalTestState](&journalTestAction{prepareErr: errors.New("no such container")})
+	state := action.NewEmptyState()
+	_, err := action.Prepare(t.Context(), &state, action_kit_api.PrepareActionRequestBody{
+		ExecutionId: uuid.New(),
+		Config:      map[string]any{"duration": 30000},
+	})
+	require.Error(t, err)
+	assert.Empty(t, journal.pending, "failed prepare must not be pending")
+
+	abandoned := uuid.New()
+	journal.pending[abandoned] = pendingAttack{preparedAt: time.Now().Add(-journalPrepareTimeout - time.Second)}
+	prepared := uuid.New()
+	journal.prepared(prepared, time.Minute, nil)
+	assert.NotContains(t, journal.pending, abandoned, "prepared attacks never started expire")
+	assert.Contains(t, journal.pending, prepared)
+}
+
+func Test_journal_revertsAfterDeadline(t *testing.T) {
+	dir := withTestJournal(t)
+	_, state := startJournaled(t, 1000)
+
+	// simulate a restart of the extension
+	journal = newAttackJournal()
+	require.NoError(t, InitAttackJournal(dir))
+	inner := &journalTestAction{}
+	action := withJournal[journalTestState](inner)
+
+	entries, err := journal.load()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	journal.recover(t.Context(), entries[0], time.Now().Add(orphanTimeout+2*time.Second))
+
+	assert.Eventually(t, func() bool { return inner.stopped.Load() == 1 }, time.Second, 10*time.Millisecond)
+	assert.Eventually(t, func() bool { return len(journal.list()) == 0 }, time.Second, 10*time.Millisecond)
+	assert.True(t, inner.lastRun.Load().Applied)
+	assert.NoFileExists(t, filepath.Join(dir, state.ExecutionId.String()+".json"))
+
+	// a late stop of the platform must not revert again
+	result, err := action.(*journaledAction[journalTestState, *journalTestState]).Stop(t.Context(), &state)
+	require.NoError(t, err)
+	require.NotNil(t, result.Messages)
+	assert.Contains(t, (*result.Messages)[0].Message, "already reverted")
+	assert.Equal(t, int32(1), inner.stopped.Load())
+}
+
+func Test_journal_adoptsRunningAttack(t *testing.T) {
+	dir := withTestJournal(t)
+	_, state := startJournaled(t, 60000)
+
+	journal = newAttackJournal()
+	require.NoError(t, InitAttackJournal(dir))
+	inner := &journalTestAction{}
+	action := withJournal[journalTestState](inner)
+
+	entries, err := journal.load()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	journal.recover(t.Context(), entries[0], time.Now())
+
+	// the platform still knows the attack and stops it as usual
+	_, err = action.(*journaledAction[journalTestState, *journalTestState]).Status(t.Context(), &state)
+	require.NoError(t, err)
+	assert.True(t, journal.wasSeen(state.ExecutionId))
+
+	_, err = action.(*journaledAction[journalTestState, *journalTestState]).Stop(t.Context(), &state)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), inner.stopped.Load())
+	assert.Empty(t, journal.list())
+}
+
+func Test_journal_dropsCorruptEntries(t *testing.T) {
+	dir := withTestJournal(t)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, uuid.NewString()+".json"), []byte("{"), 0600))
+
+	entries, err := journal.load()
+	assert.Error(t, err)
+	assert.Empty(t, entries)
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func Test_journal_dryRun(t *testing.T) {
+	dir := withTestJournal(t)
+	inner := &journalTestAction{}
+	action := withJournal[journalTestState](inner).(*journaledAction[journalTestState, *journalTestState])
+
+	state := action.NewEmptyState()
+	_, err := action.Prepare(t.Context(), &state, action_kit_api.PrepareActionRequestBody{
+		ExecutionId: uuid.New(),
+		Config:      map[string]any{"duration": 30000, "dryRun": true},
+	})
+	require.NoError(t, err)
+
+	result, err := action.Start(t.Context(), &state)
+	require.NoError(t, err)
+	require.NotNil(t, result.Messages)
+	require.Len(t, *result.Messages, 2)
+	assert.Equal(t, "Dry run, nothing is changed on container container-1. The attack would:", (*result.Messages)[0].Message)
+	assert.Equal(t, "apply the test attack on container-1", (*result.Messages)[1].Message)
+	assert.False(t, state.Applied)
+	assert.Empty(t, journal.list())
+	assert.NoFileExists(t, filepath.Join(dir, state.ExecutionId.String()+".json"))
+
+	status, err := action.Status(t.Context(), &state)
+	require.NoError(t, err)
+	assert.False(t, status.Completed)
+
+	_, err = action.Stop(t.Context(), &state)
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), inner.stopped.Load())
+	assert.Contains(t, action.Describe().Parameters, dryRunParameter)
+}
+
+func Test_restoreNetnsClaim(t *testing.T) {
+	opts := []byte(`{"TargetExecutionId":"a","Delay":100}`)
+	restoreNetnsClaim("4026532000", opts)
+	t.Cleanup(func() { releaseNetnsForAttack("4026532000") })
+
+	assert.Equal(t, ClaimShadow, claimNetnsForAttack("4026532000", []byte(`{"TargetExecutionId":"b","Delay":100}`)))
+	releaseNetnsForAttack("4026532000")
+}
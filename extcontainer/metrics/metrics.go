@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+// Package metrics holds the prometheus metrics of the extension, exposed on the /metrics endpoint.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "steadybit_extension_container"
+
+var registry = prometheus.NewRegistry()
+
+var (
+	DiscoveryDuration = promauto.With(registry).NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "discovery",
+		Name:      "duration_seconds",
+		Help:      "Duration of the discover targets requests.",
+		Buckets:   prometheus.DefBuckets,
+	})
+	DiscoveryReconcileDuration = promauto.With(registry).NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "discovery",
+		Name:      "reconcile_duration_seconds",
+		Help:      "Duration of listing all containers from the container runtime.",
+		Buckets:   prometheus.DefBuckets,
+	})
+	DiscoveryContainersListed = promauto.With(registry).NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "discovery",
+		Name:      "containers_listed",
+		Help:      "Number of containers listed by the last reconcile.",
+	})
+	DiscoveryContainersIgnored = promauto.With(registry).NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "discovery",
+		Name:      "containers_ignored",
+		Help:      "Number of containers ignored by the last reconcile, by reason.",
+	}, []string{"reason"})
+	DiscoveryContainersExcluded = promauto.With(registry).NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "discovery",
+		Name:      "containers_excluded",
+		Help:      "Number of containers excluded by the last reconcile, by exclusion rule.",
+	}, []string{"rule"})
+
+	ClientRequestDuration = promauto.With(registry).NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "runtime_client",
+		Name:      "request_duration_seconds",
+		Help:      "Duration of the calls to the container runtime, by runtime and method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"runtime", "method"})
+	ClientRequestErrors = promauto.With(registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "runtime_client",
+		Name:      "request_errors_total",
+		Help:      "Number of failed calls to the container runtime, by runtime and method.",
+	}, []string{"runtime", "method"})
+
+	AttacksActive = promauto.With(registry).NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "attacks",
+		Name:      "active",
+		Help:      "Number of currently running attacks, by action.",
+	}, []string{"action"})
+	AttacksCompleted = promauto.With(registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "attacks",
+		Name:      "completed_total",
+		Help:      "Number of successfully completed attacks, by action.",
+	}, []string{"action"})
+	AttacksFailed = promauto.With(registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "attacks",
+		Name:      "failed_total",
+		Help:      "Number of attacks that failed to start, errored or failed to stop, by action.",
+	}, []string{"action"})
+
+	NetnsClaims = promauto.With(registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "network",
+		Name:      "netns_claims_total",
+		Help:      "Number of network attack starts, by the claim on the shared network namespace (primary, shadow or passthrough).",
+	}, []string{"claim"})
+	NetnsClaimsHeld = promauto.With(registry).NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "network",
+		Name:      "netns_claims",
+		Help:      "Number of claims currently held by running network attacks on shared network namespaces, by claim (primary, shadow or passthrough).",
+	}, []string{"claim"})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// Handler serves the metrics in the prometheus exposition format.
+func Handler() http.Handler {
+	// the extension-kit http handlers already take care of compression
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{DisableCompression: true})
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/rs/zerolog/log"
 	"github.com/steadybit/action-kit/go/action_kit_commons/ociruntime"
+	"github.com/steadybit/extension-container/extcontainer/metrics"
 )
 
 // netnsAttackTracker coordinates network attacks between multiple containers
@@ -41,17 +42,19 @@ import (
 // `NetnsClaimed`), so Stop still routes correctly after an extension pod
 // restart between Start and Stop.
 //
-// Restart caveat: if the extension pod restarts *between* the primary's
-// Start (already applied) and a shadow's Start (hasn't run yet), the
-// in-memory tracker resets to empty; the shadow's Start then thinks it's
-// the primary and tries to apply, which will collide with the primary's
-// still-installed tc rules. Rare in practice — Start calls usually arrive
-// within the same second — and the collision surfaces as a normal Apply
-// error the platform can retry. Persisting the tracker to disk to close
-// the race isn't worth the complexity today.
+// Restart caveat: the tracker itself is in-memory only. After a restart it
+// is rebuilt from the attack journal (see journal.go) via
+// restoreNetnsClaim before the extension serves requests, so a shadow's
+// Start arriving after the restart still finds the primary's claim. Only
+// when the journal isn't persisted (no state dir) the shadow's Start thinks
+// it's the primary and tries to apply, which collides with the primary's
+// still-installed tc rules and surfaces as a normal Apply error.
 var netnsAttackTracker = struct {
 	sync.Mutex
 	active map[string]*netnsEntry
+	// passthroughs is the number of running passthrough attacks, they aren't
+	// in active but are counted for the held claims metric.
+	passthroughs int
 }{active: map[string]*netnsEntry{}}
 
 type netnsEntry struct {
@@ -103,6 +106,19 @@ const (
 	ClaimPassthrough
 )
 
+func (c ClaimResult) String() string {
+	switch c {
+	case ClaimPrimary:
+		return "primary"
+	case ClaimShadow:
+		return "shadow"
+	case ClaimPassthrough:
+		return "passthrough"
+	default:
+		return strconv.Itoa(int(c))
+	}
+}
+
 // netNsID returns the tracker key for a target process's network namespace.
 // Matches the format used by netfault's runcRunner.id() so both layers key
 // by the same identifier — inode preferred (immutable and unique per
@@ -130,6 +146,12 @@ func netNsID(process ociruntime.LinuxProcessInfo) string {
 // Empty id short-circuits to ClaimPrimary so an unknown-netns target still
 // applies — safer than silently no-op'ing.
 func claimNetnsForAttack(id string, opts json.RawMessage) ClaimResult {
+	result := claimNetns(id, opts)
+	metrics.NetnsClaims.WithLabelValues(result.String()).Inc()
+	return result
+}
+
+func claimNetns(id string, opts json.RawMessage) ClaimResult {
 	if id == "" {
 		return ClaimPrimary
 	}
@@ -137,6 +159,7 @@ func claimNetnsForAttack(id string, opts json.RawMessage) ClaimResult {
 	netnsAttackTracker.Lock()
 	defer netnsAttackTracker.Unlock()
 	entry, exists := netnsAttackTracker.active[id]
+	defer updateNetnsClaimsHeldLocked()
 	if !exists {
 		netnsAttackTracker.active[id] = &netnsEntry{count: 1, opts: normalized}
 		return ClaimPrimary
@@ -148,9 +171,42 @@ func claimNetnsForAttack(id string, opts json.RawMessage) ClaimResult {
 	// Different opts — let it through to netfault, don't touch the
 	// tracker. Netfault's own doesConflictWith / pushActiveNetfault will
 	// either allow both or reject the newcomer with a visible error.
+	netnsAttackTracker.passthroughs++
 	return ClaimPassthrough
 }
 
+// restoreNetnsPassthrough re-registers the passthrough claim of an attack
+// started by a previous run of the extension.
+func restoreNetnsPassthrough() {
+	netnsAttackTracker.Lock()
+	defer netnsAttackTracker.Unlock()
+	netnsAttackTracker.passthroughs++
+	updateNetnsClaimsHeldLocked()
+}
+
+// releaseNetnsPassthrough ends a passthrough claim after a Stop (checked
+// at the call site via state.NetnsPassthrough). Only the held claims
+// metric is affected, passthroughs don't touch the tracker.
+func releaseNetnsPassthrough() {
+	netnsAttackTracker.Lock()
+	defer netnsAttackTracker.Unlock()
+	netnsAttackTracker.passthroughs = max(netnsAttackTracker.passthroughs-1, 0)
+	updateNetnsClaimsHeldLocked()
+}
+
+// updateNetnsClaimsHeldLocked sets the held claims metric from the tracker:
+// each netns entry is held by one primary and count-1 shadows.
+func updateNetnsClaimsHeldLocked() {
+	primaries, shadows := 0, 0
+	for _, entry := range netnsAttackTracker.active {
+		primaries++
+		shadows += entry.count - 1
+	}
+	metrics.NetnsClaimsHeld.WithLabelValues(ClaimPrimary.String()).Set(float64(primaries))
+	metrics.NetnsClaimsHeld.WithLabelValues(ClaimShadow.String()).Set(float64(shadows))
+	metrics.NetnsClaimsHeld.WithLabelValues(ClaimPassthrough.String()).Set(float64(netnsAttackTracker.passthroughs))
+}
+
 // normalizeOptsForDedup strips the per-target TargetExecutionId nonce from
 // serialized attack opts so two sibling containers of the same pod running
 // the same experiment produce identical output. Without this the tracker
@@ -208,9 +264,27 @@ func releaseNetnsForAttack(id string) {
 	if !exists {
 		return
 	}
+	defer updateNetnsClaimsHeldLocked()
 	if entry.count <= 1 {
 		delete(netnsAttackTracker.active, id)
 		return
 	}
 	entry.count--
 }
+
+// restoreNetnsClaim re-registers the claim of an attack started by a
+// previous run of the extension, so the tracker matches the tc rules that
+// are still installed. Call only for states with NetnsClaimed=true.
+func restoreNetnsClaim(id string, opts json.RawMessage) {
+	if id == "" {
+		return
+	}
+	netnsAttackTracker.Lock()
+	defer netnsAttackTracker.Unlock()
+	defer updateNetnsClaimsHeldLocked()
+	if entry, exists := netnsAttackTracker.active[id]; exists {
+		entry.count++
+		return
+	}
+	netnsAttackTracker.active[id] = &netnsEntry{count: 1, opts: normalizeOptsForDedup(opts)}
+}
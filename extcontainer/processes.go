@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var procRoot = "/proc"
+
+type containerProcess struct {
+	Pid     int
+	Comm    string
+	Cmdline string
+}
+
+// findProcessesInPidNamespace returns all processes sharing the pid namespace with the given pid whose comm or command
+// line matches the pattern. The processes are read from the host's /proc, so the pids are the ones of the host.
+func findProcessesInPidNamespace(pid int, pattern *regexp.Regexp) ([]containerProcess, error) {
+	pidNs, err := os.Readlink(filepath.Join(procRoot, strconv.Itoa(pid), "ns", "pid"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pid namespace of process %d: %w", pid, err)
+	}
+
+	entries, err := os.ReadDir(procRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list processes: %w", err)
+	}
+
+	var result []containerProcess
+	for _, entry := range entries {
+		candidate, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+
+		dir := filepath.Join(procRoot, entry.Name())
+		// processes might be gone in the meantime, these are just skipped
+		if ns, err := os.Readlink(filepath.Join(dir, "ns", "pid")); err != nil || ns != pidNs {
+			continue
+		}
+		comm, err := os.ReadFile(filepath.Join(dir, "comm"))
+		if err != nil {
+			continue
+		}
+		cmdline, _ := os.ReadFile(filepath.Join(dir, "cmdline"))
+
+		process := containerProcess{
+			Pid:     candidate,
+			Comm:    strings.TrimSpace(string(comm)),
+			Cmdline: strings.TrimSpace(string(bytes.ReplaceAll(cmdline, []byte{0}, []byte{' '}))),
+		}
+		if pattern.MatchString(process.Comm) || pattern.MatchString(process.Cmdline) {
+			result = append(result, process)
+		}
+	}
+
+	slices.SortFunc(result, func(a, b containerProcess) int { return a.Pid - b.Pid })
+	return result, nil
+}
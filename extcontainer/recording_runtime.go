@@ -0,0 +1,178 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_commons/ociruntime"
+	"github.com/steadybit/extension-container/extcontainer/audit"
+)
+
+// recordingRuntime records the commands run in the sidecars for the audit log of the attack, see audit.WithCommands.
+// The commands read from stdin by batch processes (e.g. ip -batch -) are recorded one by one. Without a runtime to
+// delegate to nothing is run, which is used to plan the dry runs.
+type recordingRuntime struct {
+	delegate ociruntime.OciRuntime
+}
+
+var _ ociruntime.OciRuntime = (*recordingRuntime)(nil)
+
+// WithCommandRecording records the commands run using the runtime in the audit log
+func WithCommandRecording(r ociruntime.OciRuntime) ociruntime.OciRuntime {
+	return &recordingRuntime{delegate: r}
+}
+
+func (r *recordingRuntime) State(ctx context.Context, id string) (*ociruntime.ContainerState, error) {
+	if r.delegate == nil {
+		return nil, fmt.Errorf("container %s not found", id)
+	}
+	return r.delegate.State(ctx, id)
+}
+
+func (r *recordingRuntime) Create(ctx context.Context, image, id string) (ociruntime.ContainerBundle, error) {
+	if r.delegate == nil {
+		return &recordingBundle{id: id, spec: specs.Spec{Process: &specs.Process{}, Root: &specs.Root{}, Linux: &specs.Linux{}}}, nil
+	}
+	bundle, err := r.delegate.Create(ctx, image, id)
+	if err != nil {
+		return nil, err
+	}
+	return &recordingBundle{ContainerBundle: bundle, id: id}, nil
+}
+
+func (r *recordingRuntime) Run(ctx context.Context, container ociruntime.ContainerBundle, ioOpts ociruntime.IoOpts) error {
+	bundle, ok := container.(*recordingBundle)
+	if !ok {
+		return fmt.Errorf("unexpected container bundle %T", container)
+	}
+
+	args := bundle.args()
+	if batch := slices.Index(args, "-batch"); batch >= 0 && ioOpts.Stdin != nil {
+		stdin, err := io.ReadAll(ioOpts.Stdin)
+		if err != nil {
+			return err
+		}
+		ioOpts.Stdin = bytes.NewReader(stdin)
+
+		prefix := strings.Join(slices.Delete(slices.Clone(args), batch, min(batch+2, len(args))), " ")
+		for line := range strings.Lines(string(stdin)) {
+			if line = strings.TrimSpace(line); line != "" {
+				audit.RecordCommands(ctx, prefix+" "+line)
+			}
+		}
+	} else {
+		audit.RecordCommands(ctx, strings.Join(args, " "))
+	}
+
+	if r.delegate == nil {
+		return nil
+	}
+	return r.delegate.Run(ctx, bundle.ContainerBundle, ioOpts)
+}
+
+func (r *recordingRuntime) RunCommand(ctx context.Context, container ociruntime.ContainerBundle) (*exec.Cmd, error) {
+	bundle, ok := container.(*recordingBundle)
+	if !ok {
+		return nil, fmt.Errorf("unexpected container bundle %T", container)
+	}
+	if r.delegate == nil {
+		return nil, fmt.Errorf("running commands in %s is not supported while recording", container.ContainerId())
+	}
+	audit.RecordCommands(ctx, strings.Join(bundle.args(), " "))
+	return r.delegate.RunCommand(ctx, bundle.ContainerBundle)
+}
+
+func (r *recordingRuntime) Delete(ctx context.Context, id string, force bool) error {
+	if r.delegate == nil {
+		return nil
+	}
+	return r.delegate.Delete(ctx, id, force)
+}
+
+func (r *recordingRuntime) Kill(ctx context.Context, id string, signal syscall.Signal) error {
+	if r.delegate == nil {
+		return nil
+	}
+	return r.delegate.Kill(ctx, id, signal)
+}
+
+// recordingBundle is the bundle of a recorded container. The process args are read from the config.json of the
+// delegated bundle, without one the spec is kept in memory.
+type recordingBundle struct {
+	ociruntime.ContainerBundle
+	id   string
+	spec specs.Spec
+}
+
+func (b *recordingBundle) args() []string {
+	if b.ContainerBundle == nil {
+		return b.spec.Process.Args
+	}
+
+	var spec specs.Spec
+	content, err := os.ReadFile(filepath.Join(b.ContainerBundle.Path(), "config.json"))
+	if err == nil {
+		err = json.Unmarshal(content, &spec)
+	}
+	if err != nil || spec.Process == nil {
+		log.Debug().Err(err).Str("id", b.id).Msg("Failed to read the process of the sidecar, recording its id instead.")
+		return []string{"sidecar", b.id}
+	}
+	return spec.Process.Args
+}
+
+func (b *recordingBundle) EditSpec(editors ...ociruntime.SpecEditor) error {
+	if b.ContainerBundle != nil {
+		return b.ContainerBundle.EditSpec(editors...)
+	}
+	for _, editor := range editors {
+		editor(&b.spec)
+	}
+	return nil
+}
+
+func (b *recordingBundle) MountFromProcess(ctx context.Context, fromPid int, fromPath, mountpoint string) error {
+	if b.ContainerBundle != nil {
+		return b.ContainerBundle.MountFromProcess(ctx, fromPid, fromPath, mountpoint)
+	}
+	return nil
+}
+
+func (b *recordingBundle) CopyFileFromProcess(ctx context.Context, pid int, fromPath, toPath string) error {
+	if b.ContainerBundle != nil {
+		return b.ContainerBundle.CopyFileFromProcess(ctx, pid, fromPath, toPath)
+	}
+	return nil
+}
+
+func (b *recordingBundle) Path() string {
+	if b.ContainerBundle != nil {
+		return b.ContainerBundle.Path()
+	}
+	return ""
+}
+
+func (b *recordingBundle) ContainerId() string {
+	return b.id
+}
+
+func (b *recordingBundle) Remove() error {
+	if b.ContainerBundle != nil {
+		return b.ContainerBundle.Remove()
+	}
+	return nil
+}
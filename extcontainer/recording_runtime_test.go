@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/steadybit/action-kit/go/action_kit_commons/ociruntime"
+	"github.com/steadybit/extension-container/extcontainer/audit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_recordingRuntime_withoutDelegate(t *testing.T) {
+	r := &recordingRuntime{}
+	ctx, commands := audit.WithCommands(t.Context())
+
+	bundle, err := r.Create(ctx, "sidecar", "sb-network-1")
+	require.NoError(t, err)
+	require.NoError(t, bundle.EditSpec(func(spec *specs.Spec) { spec.Process.Args = []string{"ip", "-family", "inet", "-batch", "-"} }))
+	require.NoError(t, r.Run(ctx, bundle, ociruntime.IoOpts{Stdin: strings.NewReader("rule add blackhole to 10.0.0.0/8\n\nrule add blackhole from 10.0.0.0/8\n")}))
+
+	bundle, err = r.Create(ctx, "sidecar", "sb-network-2")
+	require.NoError(t, err)
+	require.NoError(t, bundle.EditSpec(func(spec *specs.Spec) { spec.Process.Args = []string{"tc", "qdisc", "show"} }))
+	require.NoError(t, r.Run(ctx, bundle, ociruntime.IoOpts{}))
+
+	assert.Equal(t, []string{
+		"ip -family inet rule add blackhole to 10.0.0.0/8",
+		"ip -family inet rule add blackhole from 10.0.0.0/8",
+		"tc qdisc show",
+	}, commands())
+}
+
+func Test_recordingRuntime_delegates(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.json"), []byte(`{"process":{"args":["tc","-batch","-"]}}`), 0o600))
+	delegateBundle := &MockBundle{id: "sb-network-1", path: dir}
+	delegate := &MockedRunc{}
+	delegate.On("Create", mock.Anything, "sidecar", "sb-network-1").Return(delegateBundle, nil)
+	var stdin string
+	delegate.On("Run", mock.Anything, delegateBundle, mock.Anything).Run(func(args mock.Arguments) {
+		content, _ := io.ReadAll(args.Get(2).(ociruntime.IoOpts).Stdin)
+		stdin = string(content)
+	}).Return(nil)
+
+	r := WithCommandRecording(delegate)
+	ctx, commands := audit.WithCommands(t.Context())
+	bundle, err := r.Create(ctx, "sidecar", "sb-network-1")
+	require.NoError(t, err)
+	require.NoError(t, r.Run(ctx, bundle, ociruntime.IoOpts{Stdin: strings.NewReader("qdisc add dev eth0 root handle 1: prio\n")}))
+
+	assert.Equal(t, []string{"tc qdisc add dev eth0 root handle 1: prio"}, commands())
+	assert.Equal(t, "qdisc add dev eth0 root handle 1: prio\n", stdin, "the commands are still passed to the delegate")
+	delegate.AssertExpectations(t)
+}
@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// targetQuery is a conjunction of attribute comparisons, e.g. `k8s.deployment="db" and k8s.namespace!="test"`.
+// An attribute matches if any of its values equals the expected value, a missing attribute never equals.
+type targetQuery []targetQueryTerm
+
+type targetQueryTerm struct {
+	Attribute string
+	Value     string
+	Negated   bool
+}
+
+func parseTargetQuery(query string) (targetQuery, error) {
+	tokens, err := tokenizeTargetQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("query is empty")
+	}
+
+	var result targetQuery
+	for len(tokens) > 0 {
+		if len(result) > 0 {
+			if !strings.EqualFold(tokens[0], "and") {
+				return nil, fmt.Errorf("expected 'and' but got %q", tokens[0])
+			}
+			tokens = tokens[1:]
+		}
+		if len(tokens) < 3 {
+			return nil, fmt.Errorf("incomplete comparison at end of query %q", query)
+		}
+
+		term := targetQueryTerm{Attribute: tokens[0], Value: unquote(tokens[2])}
+		switch tokens[1] {
+		case "=":
+		case "!=":
+			term.Negated = true
+		default:
+			return nil, fmt.Errorf("expected '=' or '!=' after %q but got %q", tokens[0], tokens[1])
+		}
+		result = append(result, term)
+		tokens = tokens[3:]
+	}
+	return result, nil
+}
+
+func (q targetQuery) matches(attributes map[string][]string) bool {
+	for _, term := range q {
+		if slices.Contains(attributes[term.Attribute], term.Value) == term.Negated {
+			return false
+		}
+	}
+	return true
+}
+
+func (q targetQuery) String() string {
+	terms := make([]string, 0, len(q))
+	for _, term := range q {
+		operator := "="
+		if term.Negated {
+			operator = "!="
+		}
+		terms = append(terms, fmt.Sprintf("%s%s%q", term.Attribute, operator, term.Value))
+	}
+	return strings.Join(terms, " and ")
+}
+
+// tokenizeTargetQuery splits the query into attribute names, operators and (quoted) values
+func tokenizeTargetQuery(query string) ([]string, error) {
+	var tokens []string
+	runes := []rune(query)
+	for i := 0; i < len(runes); {
+		switch r := runes[i]; {
+		case unicode.IsSpace(r):
+			i++
+		case r == '=':
+			tokens = append(tokens, "=")
+			i++
+		case r == '!':
+			if i+1 >= len(runes) || runes[i+1] != '=' {
+				return nil, fmt.Errorf("unexpected '!' at position %d", i)
+			}
+			tokens = append(tokens, "!=")
+			i += 2
+		case r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				if runes[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(runes) {
+				return nil, fmt.Errorf("unterminated string at position %d", i)
+			}
+			tokens = append(tokens, string(runes[i:end+1]))
+			i = end + 1
+		default:
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && !strings.ContainsRune("=!\"", runes[end]) {
+				end++
+			}
+			tokens = append(tokens, string(runes[i:end]))
+			i = end
+		}
+	}
+	return tokens, nil
+}
+
+func unquote(value string) string {
+	if len(value) >= 2 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) {
+		return strings.NewReplacer(`\"`, `"`, `\\`, `\`).Replace(value[1 : len(value)-1])
+	}
+	return value
+}
@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseTargetQuery(t *testing.T) {
+	tests := []struct {
+		query   string
+		want    targetQuery
+		wantErr string
+	}{
+		{
+			query: `k8s.container.name="db"`,
+			want:  targetQuery{{Attribute: "k8s.container.name", Value: "db"}},
+		},
+		{
+			query: `k8s.container.name = db AND k8s.namespace!="shop \"test\""`,
+			want: targetQuery{
+				{Attribute: "k8s.container.name", Value: "db"},
+				{Attribute: "k8s.namespace", Value: `shop "test"`, Negated: true},
+			},
+		},
+		{query: "", wantErr: "query is empty"},
+		{query: `k8s.container.name="db" or k8s.namespace="shop"`, wantErr: "expected 'and'"},
+		{query: `k8s.container.name`, wantErr: "incomplete comparison"},
+		{query: `k8s.container.name ~ "db"`, wantErr: "expected '=' or '!='"},
+		{query: `k8s.container.name="db`, wantErr: "unterminated string"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			got, err := parseTargetQuery(tt.query)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_targetQuery_matches(t *testing.T) {
+	attributes := map[string][]string{
+		"k8s.container.name":   {"db"},
+		"container.label.tier": {"backend", "storage"},
+	}
+
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{`k8s.container.name="db"`, true},
+		{`k8s.container.name="web"`, false},
+		{`container.label.tier="storage" and k8s.container.name="db"`, true},
+		{`container.label.tier!="storage"`, false},
+		{`k8s.namespace!="shop"`, true},
+		{`k8s.namespace="shop"`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			query, err := parseTargetQuery(tt.query)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, query.matches(attributes))
+		})
+	}
+}
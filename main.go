@@ -5,19 +5,21 @@ package main
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"github.com/steadybit/action-kit/go/action_kit_api/v2"
 	"github.com/steadybit/action-kit/go/action_kit_commons/network/netfault"
-	"github.com/steadybit/action-kit/go/action_kit_commons/ociruntime"
 	"github.com/steadybit/action-kit/go/action_kit_sdk"
 	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
 	"github.com/steadybit/discovery-kit/go/discovery_kit_sdk"
 	"github.com/steadybit/extension-container/config"
 	"github.com/steadybit/extension-container/extcontainer"
+	"github.com/steadybit/extension-container/extcontainer/audit"
 	"github.com/steadybit/extension-container/extcontainer/container"
 	"github.com/steadybit/extension-container/extcontainer/container/types"
+	"github.com/steadybit/extension-container/extcontainer/metrics"
 	"github.com/steadybit/extension-kit/extbuild"
 	"github.com/steadybit/extension-kit/exthealth"
 	"github.com/steadybit/extension-kit/exthttp"
@@ -40,6 +42,7 @@ func main() {
 	config.ValidateConfiguration()
 
 	log.Debug().Any("config", config.Config).Msg("Configuration loaded.")
+	config.WatchConfigFile(context.Background())
 
 	// Single knob for the operator: strict mode refuses attacks on
 	// non-noqueue roots; lifting it activates the snapshot/restore path so
@@ -70,30 +73,59 @@ func main() {
 		Str("socket", client.Socket()).
 		Msg("Container runtime client initialized.")
 
-	ociRuntimeCfg := ociruntime.ConfigFromEnvironment()
-	if ociRuntimeCfg.Root == "" {
-		ociRuntimeCfg.Root = client.Runtime().DefaultRuncRoot()
+	r := extcontainer.WithCommandRecording(container.NewOciRuntime(client))
+
+	if err := extcontainer.InitAttackJournal(config.Config.StateDir); err != nil {
+		log.Fatal().Err(err).Msg("Failed to initialize attack journal.")
+	}
+
+	if err := audit.Init(audit.Options{
+		File:       config.Config.AuditLogFile,
+		MaxSize:    int64(config.Config.AuditLogMaxSizeMb) * 1024 * 1024,
+		MaxBackups: config.Config.AuditLogMaxBackups,
+		Stdout:     config.Config.AuditLogStdout,
+	}); err != nil {
+		log.Fatal().Err(err).Msg("Failed to initialize audit log.")
 	}
-	r := ociruntime.NewOciRuntimeWithCrunForSidecars(ociRuntimeCfg)
-
-	discovery_kit_sdk.Register(extcontainer.NewContainerDiscovery(client))
-	action_kit_sdk.RegisterAction(extcontainer.NewPauseContainerAction(client))
-	action_kit_sdk.RegisterAction(extcontainer.NewStopContainerAction(client))
-	action_kit_sdk.RegisterAction(extcontainer.NewStressCpuContainerAction(r, client))
-	action_kit_sdk.RegisterAction(extcontainer.NewStressMemoryContainerAction(r, client))
-	action_kit_sdk.RegisterAction(extcontainer.NewStressIoContainerAction(r, client))
-	action_kit_sdk.RegisterAction(extcontainer.NewNetworkBlackholeContainerAction(r, client))
-	action_kit_sdk.RegisterAction(extcontainer.NewNetworkBlockDnsContainerAction(r, client))
-	action_kit_sdk.RegisterAction(extcontainer.NewNetworkDelayContainerAction(r, client))
-	action_kit_sdk.RegisterAction(extcontainer.NewNetworkDNSErrorInjectionAction(r, client))
-	action_kit_sdk.RegisterAction(extcontainer.NewNetworkLimitBandwidthContainerAction(r, client))
-	action_kit_sdk.RegisterAction(extcontainer.NewNetworkCorruptPackagesContainerAction(r, client))
-	action_kit_sdk.RegisterAction(extcontainer.NewNetworkPackageLossContainerAction(r, client))
-	action_kit_sdk.RegisterAction(extcontainer.NewNetworkTcpResetContainerAction(r, client))
-	action_kit_sdk.RegisterAction(extcontainer.NewFillDiskContainerAction(r, client))
-	action_kit_sdk.RegisterAction(extcontainer.NewFillMemoryContainerAction(r, client))
+
+	discovery := extcontainer.NewContainerDiscovery(client, r)
+	discovery_kit_sdk.Register(discovery)
+	extcontainer.InitAttackGuard(discovery)
+	action_kit_sdk.RegisterAction(extcontainer.WithAudit(extcontainer.NewPauseContainerAction(r, client)))
+	action_kit_sdk.RegisterAction(extcontainer.WithAudit(extcontainer.NewStopContainerAction(client)))
+	action_kit_sdk.RegisterAction(extcontainer.WithAudit(extcontainer.NewRestartContainerAction(client)))
+	action_kit_sdk.RegisterAction(extcontainer.WithAudit(extcontainer.NewSignalContainerAction(r, client)))
+	action_kit_sdk.RegisterAction(extcontainer.WithAudit(extcontainer.NewKillProcessContainerAction(client)))
+	action_kit_sdk.RegisterAction(extcontainer.WithAudit(extcontainer.NewStressCpuContainerAction(r, client)))
+	action_kit_sdk.RegisterAction(extcontainer.WithAudit(extcontainer.NewStressMemoryContainerAction(r, client)))
+	action_kit_sdk.RegisterAction(extcontainer.WithAudit(extcontainer.NewStressIoContainerAction(r, client)))
+	action_kit_sdk.RegisterAction(extcontainer.WithAudit(extcontainer.NewNetworkBlackholeContainerAction(r, client)))
+	action_kit_sdk.RegisterAction(extcontainer.WithAudit(extcontainer.NewNetworkPartitionContainerAction(r, client, discovery)))
+	action_kit_sdk.RegisterAction(extcontainer.WithAudit(extcontainer.NewNetworkBlockDnsContainerAction(r, client)))
+	action_kit_sdk.RegisterAction(extcontainer.WithAudit(extcontainer.NewNetworkDelayContainerAction(r, client)))
+	action_kit_sdk.RegisterAction(extcontainer.WithAudit(extcontainer.NewNetworkDNSErrorInjectionAction(r, client)))
+	action_kit_sdk.RegisterAction(extcontainer.WithAudit(extcontainer.NewNetworkLimitBandwidthContainerAction(r, client)))
+	action_kit_sdk.RegisterAction(extcontainer.WithAudit(extcontainer.NewNetworkCorruptPackagesContainerAction(r, client)))
+	action_kit_sdk.RegisterAction(extcontainer.WithAudit(extcontainer.NewNetworkPackageLossContainerAction(r, client)))
+	action_kit_sdk.RegisterAction(extcontainer.WithAudit(extcontainer.NewNetworkTcpResetContainerAction(r, client)))
+	action_kit_sdk.RegisterAction(extcontainer.WithAudit(extcontainer.NewFillDiskContainerAction(r, client)))
+	action_kit_sdk.RegisterAction(extcontainer.WithAudit(extcontainer.NewFillMemoryContainerAction(r, client)))
+
+	// revert attacks left behind by a previous run of the extension, requires all actions to be registered
+	extcontainer.RecoverAttacks(context.Background())
 
 	exthttp.RegisterRevisionedHandler("/", getExtensionList)
+	exthttp.RegisterHttpHandler("/guard", exthttp.GetterAsHandler(extcontainer.AttackGuardStatus))
+	exthttp.RegisterHttpHandler("/attacks", exthttp.GetterAsHandler(extcontainer.ActiveAttacks))
+	exthttp.RegisterHttpHandler("/config", exthttp.GetterAsHandler(config.Effective))
+	exthttp.RegisterHttpHandler("/audit", audit.Handler)
+	if config.Config.EmergencyStopToken != "" {
+		exthttp.RegisterHttpHandler("/emergency-stop", extcontainer.NewEmergencyStopHandler(config.Config.EmergencyStopToken))
+	}
+	extcontainer.HandleEmergencyStopSignal()
+	exthttp.RegisterHttpHandlerWithLogLevel("/metrics", func(w http.ResponseWriter, r *http.Request, _ []byte) {
+		metrics.Handler().ServeHTTP(w, r)
+	}, zerolog.DebugLevel)
 
 	extsignals.ActivateSignalHandlers()
 